@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-kratos/blades/tools"
 	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/google/uuid"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -51,6 +52,15 @@ func WithOutputSchema(schema *jsonschema.Schema) Option {
 	}
 }
 
+// WithResponseFormat sets the output format for the Agent, e.g. JSONObject or
+// JSONSchema(Answer{}). The final output is validated against the format and Run
+// returns an error when it does not conform.
+func WithResponseFormat(format ResponseFormat) Option {
+	return func(a *Agent) {
+		a.responseFormat = &format
+	}
+}
+
 // WithOutputKey sets the output key for storing the Agent's output in the session state.
 func WithOutputKey(key string) Option {
 	return func(a *Agent) {
@@ -79,6 +89,35 @@ func WithMiddleware(ms ...Middleware) Option {
 	}
 }
 
+// WithProviderMiddleware sets the provider middleware for the Agent.
+// Provider middleware wraps every call to the model provider, so it always runs
+// after all middleware set with WithMiddleware and sees the final model request,
+// including the system instructions and any tool messages.
+func WithProviderMiddleware(ms ...ProviderMiddleware) Option {
+	return func(a *Agent) {
+		a.providerMiddlewares = ms
+	}
+}
+
+// WithMemory sets the conversation memory for the Agent. Prior messages are loaded
+// before the prompt on every run, and the new turn is appended afterwards.
+// If sessionID is empty, the ID of the session in the context is used.
+func WithMemory(memory Memory, sessionID string) Option {
+	return func(a *Agent) {
+		a.memory = memory
+		a.memorySessionID = sessionID
+	}
+}
+
+// WithStreamCallback sets a callback that Run invokes with each text delta as it
+// arrives, while still returning the assembled final message. The callback is
+// called in order on the goroutine that called Run.
+func WithStreamCallback(fn func(delta string)) Option {
+	return func(a *Agent) {
+		a.streamCallback = fn
+	}
+}
+
 // WithStateInputHandler sets the state input handler for the Agent.
 func WithStateInputHandler(h StateInputHandler) Option {
 	return func(a *Agent) {
@@ -116,6 +155,12 @@ type Agent struct {
 	middlewares   []Middleware
 	provider      ModelProvider
 	tools         []*tools.Tool
+
+	providerMiddlewares []ProviderMiddleware
+	memory              Memory
+	memorySessionID     string
+	streamCallback      func(delta string)
+	responseFormat      *ResponseFormat
 }
 
 // NewAgent creates a new Agent with the given name and options.
@@ -157,6 +202,12 @@ func (a *Agent) buildContext(ctx context.Context) (context.Context, *Session) {
 	}), session
 }
 
+// instructionID derives a stable ID for a rendered instruction message from the session,
+// agent name and text, so Session.Record keeps one copy of unchanged instructions across runs.
+func (a *Agent) instructionID(session *Session, m *Message) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(session.ID+"\x00"+a.name+"\x00"+m.Text())).String()
+}
+
 // buildRequest builds the request for the Agent by combining system instructions and user messages.
 func (a *Agent) buildRequest(ctx context.Context, session *Session, prompt *Prompt) (*ModelRequest, error) {
 	req := ModelRequest{
@@ -165,14 +216,34 @@ func (a *Agent) buildRequest(ctx context.Context, session *Session, prompt *Prom
 		InputSchema:  a.inputSchema,
 		OutputSchema: a.outputSchema,
 	}
+	if a.responseFormat != nil {
+		if a.responseFormat.err != nil {
+			return nil, a.responseFormat.err
+		}
+		req.ResponseFormat = a.responseFormat
+		if req.OutputSchema == nil {
+			req.OutputSchema = a.responseFormat.Schema
+		}
+	}
 	// system messages
 	if a.instructions != "" {
 		system, err := NewPromptTemplate().System(a.instructions).BuildContext(ctx)
 		if err != nil {
 			return nil, err
 		}
+		for _, m := range system.Messages {
+			m.ID = a.instructionID(session, m)
+		}
 		req.Messages = append(req.Messages, system.Messages...)
 	}
+	// memory messages
+	if a.memory != nil {
+		history, err := a.memory.Load(ctx, a.sessionID(session))
+		if err != nil {
+			return nil, fmt.Errorf("memory: load: %w", err)
+		}
+		req.Messages = append(req.Messages, history...)
+	}
 	// user messages
 	if len(prompt.Messages) > 0 {
 		req.Messages = append(req.Messages, prompt.Messages...)
@@ -210,6 +281,14 @@ func (a *Agent) RunStream(ctx context.Context, prompt *Prompt, opts ...ModelOpti
 	return handler.RunStream(ctx, prompt, opts...)
 }
 
+// validateOutput checks the final response against the response format, if any.
+func (a *Agent) validateOutput(res *ModelResponse) error {
+	if a.responseFormat == nil {
+		return nil
+	}
+	return a.responseFormat.Validate(res.Message)
+}
+
 // storeOutputToState stores the output of the Agent to the session state if an output key is defined.
 func (a *Agent) storeOutputToState(session *Session, res *ModelResponse) error {
 	if a.outputKey == "" {
@@ -227,6 +306,28 @@ func (a *Agent) storeOutputToState(session *Session, res *ModelResponse) error {
 	return nil
 }
 
+// sessionID returns the memory session ID, falling back to the current session.
+func (a *Agent) sessionID(session *Session) string {
+	if a.memorySessionID != "" {
+		return a.memorySessionID
+	}
+	return session.ID
+}
+
+// appendMemory persists the prompt and final output as a new turn in memory.
+func (a *Agent) appendMemory(ctx context.Context, session *Session, prompt *Prompt, output *Message) error {
+	if a.memory == nil {
+		return nil
+	}
+	messages := make([]*Message, 0, len(prompt.Messages)+1)
+	messages = append(messages, prompt.Messages...)
+	messages = append(messages, output)
+	if err := a.memory.Append(ctx, a.sessionID(session), messages); err != nil {
+		return fmt.Errorf("memory: append: %w", err)
+	}
+	return nil
+}
+
 func (a *Agent) handleTools(ctx context.Context, part ToolPart) (ToolPart, error) {
 	for _, tool := range a.tools {
 		if tool.Name == part.Name {
@@ -261,12 +362,46 @@ func (a *Agent) executeTools(ctx context.Context, message *Message) (*Message, e
 	return toolMessage, eg.Wait()
 }
 
+// generate executes a single model request. With a stream callback it streams the
+// response, reporting each text delta, and returns the final completed response.
+func (a *Agent) generate(ctx context.Context, provider ModelProvider, req *ModelRequest, opts ...ModelOption) (*ModelResponse, error) {
+	if a.streamCallback == nil {
+		return provider.Generate(ctx, req, opts...)
+	}
+	stream, err := provider.NewStream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var finalResponse *ModelResponse
+	for stream.Next() {
+		chunk, err := stream.Current()
+		if err != nil {
+			return nil, err
+		}
+		if chunk.Message.Status == StatusCompleted {
+			finalResponse = chunk
+			continue
+		}
+		if delta := chunk.Message.Text(); delta != "" {
+			a.streamCallback(delta)
+		}
+	}
+	if finalResponse == nil {
+		return nil, ErrMissingFinalResponse
+	}
+	return finalResponse, nil
+}
+
 // handler constructs the default handlers for Run and Stream using the provider.
 func (a *Agent) handler(session *Session, req *ModelRequest) Runnable {
+	provider := a.provider
+	if len(a.providerMiddlewares) > 0 {
+		provider = ChainProviderMiddlewares(a.providerMiddlewares...)(provider)
+	}
 	handler := Runnable(&HandleFunc{
 		Handle: func(ctx context.Context, prompt *Prompt, opts ...ModelOption) (*Message, error) {
 			for i := 0; i < a.maxIterations; i++ {
-				res, err := a.provider.Generate(ctx, req, opts...)
+				res, err := a.generate(ctx, provider, req, opts...)
 				if err != nil {
 					return nil, err
 				}
@@ -278,10 +413,16 @@ func (a *Agent) handler(session *Session, req *ModelRequest) Runnable {
 					req.Messages = append(req.Messages, toolMessage)
 					continue // continue to the next iteration
 				}
+				if err := a.validateOutput(res); err != nil {
+					return nil, err
+				}
 				if err := a.storeOutputToState(session, res); err != nil {
 					return nil, err
 				}
 				session.Record(req.Messages, res.Message)
+				if err := a.appendMemory(ctx, session, prompt, res.Message); err != nil {
+					return nil, err
+				}
 				return a.outputHandler(ctx, res.Message, &session.State)
 			}
 			return nil, ErrMaxIterationsExceeded
@@ -290,7 +431,7 @@ func (a *Agent) handler(session *Session, req *ModelRequest) Runnable {
 			pipe := NewStreamPipe[*Message]()
 			pipe.Go(func() error {
 				for i := 0; i < a.maxIterations; i++ {
-					stream, err := a.provider.NewStream(ctx, req, opts...)
+					stream, err := provider.NewStream(ctx, req, opts...)
 					if err != nil {
 						return err
 					}
@@ -317,10 +458,16 @@ func (a *Agent) handler(session *Session, req *ModelRequest) Runnable {
 						req.Messages = append(req.Messages, toolMessage)
 						continue // continue to the next iteration
 					}
+					if err := a.validateOutput(finalResponse); err != nil {
+						return err
+					}
 					if err := a.storeOutputToState(session, finalResponse); err != nil {
 						return err
 					}
 					session.Record(req.Messages, finalResponse.Message)
+					if err := a.appendMemory(ctx, session, prompt, finalResponse.Message); err != nil {
+						return err
+					}
 					// handle the final response before sending
 					finalResponse.Message, err = a.outputHandler(ctx, finalResponse.Message, &session.State)
 					if err != nil {
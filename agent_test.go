@@ -0,0 +1,135 @@
+package blades
+
+import (
+	"context"
+	"testing"
+)
+
+// mockProvider is a ModelProvider that answers every request with a fixed text.
+type mockProvider struct {
+	text     string
+	requests []*ModelRequest
+}
+
+func (p *mockProvider) Generate(ctx context.Context, req *ModelRequest, opts ...ModelOption) (*ModelResponse, error) {
+	p.requests = append(p.requests, req)
+	return &ModelResponse{Message: &Message{
+		ID:     NewMessageID(),
+		Role:   RoleAssistant,
+		Status: StatusCompleted,
+		Parts:  Parts(p.text),
+	}}, nil
+}
+
+func (p *mockProvider) NewStream(ctx context.Context, req *ModelRequest, opts ...ModelOption) (Streamable[*ModelResponse], error) {
+	pipe := NewStreamPipe[*ModelResponse]()
+	pipe.Go(func() error {
+		res, err := p.Generate(ctx, req, opts...)
+		if err != nil {
+			return err
+		}
+		pipe.Send(res)
+		return nil
+	})
+	return pipe, nil
+}
+
+// recordingProvider records each request before delegating to the wrapped provider.
+type recordingProvider struct {
+	ModelProvider
+	record func(*ModelRequest)
+}
+
+func (p *recordingProvider) Generate(ctx context.Context, req *ModelRequest, opts ...ModelOption) (*ModelResponse, error) {
+	p.record(req)
+	return p.ModelProvider.Generate(ctx, req, opts...)
+}
+
+func TestAgentProviderMiddleware(t *testing.T) {
+	var (
+		order   []string
+		lastReq *ModelRequest
+	)
+	agentMiddleware := func(next Runnable) Runnable {
+		return &HandleFunc{
+			Handle: func(ctx context.Context, p *Prompt, opts ...ModelOption) (*Message, error) {
+				order = append(order, "agent")
+				return next.Run(ctx, p, opts...)
+			},
+		}
+	}
+	providerMiddleware := func(name string) ProviderMiddleware {
+		return func(next ModelProvider) ModelProvider {
+			return &recordingProvider{ModelProvider: next, record: func(req *ModelRequest) {
+				order = append(order, name)
+				lastReq = req
+			}}
+		}
+	}
+	agent := NewAgent("test",
+		WithProvider(&mockProvider{text: "ok"}),
+		WithInstructions("Be brief."),
+		WithProviderMiddleware(providerMiddleware("provider-1"), providerMiddleware("provider-2")),
+		WithMiddleware(agentMiddleware),
+	)
+	output, err := agent.Run(context.Background(), NewPrompt(UserMessage("hello")))
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if output.Text() != "ok" {
+		t.Fatalf("Run() = %q; want %q", output.Text(), "ok")
+	}
+	want := []string{"agent", "provider-1", "provider-2"}
+	if len(order) != len(want) {
+		t.Fatalf("call order = %v; want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("call order = %v; want %v", order, want)
+		}
+	}
+	if len(lastReq.Messages) != 2 || lastReq.Messages[0].Role != RoleSystem || lastReq.Messages[1].Text() != "hello" {
+		t.Fatalf("provider middleware saw unexpected request messages: %v", lastReq.Messages)
+	}
+}
+
+// chunkedProvider streams its text one word at a time before the completed message.
+type chunkedProvider struct {
+	mockProvider
+	chunks []string
+}
+
+func (p *chunkedProvider) NewStream(ctx context.Context, req *ModelRequest, opts ...ModelOption) (Streamable[*ModelResponse], error) {
+	pipe := NewStreamPipe[*ModelResponse]()
+	pipe.Go(func() error {
+		for _, chunk := range p.chunks {
+			pipe.Send(&ModelResponse{Message: &Message{Role: RoleAssistant, Status: StatusIncomplete, Parts: Parts(chunk)}})
+		}
+		res, err := p.Generate(ctx, req, opts...)
+		if err != nil {
+			return err
+		}
+		pipe.Send(res)
+		return nil
+	})
+	return pipe, nil
+}
+
+func TestAgentStreamCallback(t *testing.T) {
+	provider := &chunkedProvider{mockProvider: mockProvider{text: "hello world"}, chunks: []string{"hello", " world"}}
+	var deltas []string
+	agent := NewAgent("test",
+		WithProvider(provider),
+		WithStreamCallback(func(delta string) { deltas = append(deltas, delta) }),
+	)
+	output, err := agent.Run(context.Background(), NewPrompt(UserMessage("hi")))
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if output.Text() != "hello world" {
+		t.Fatalf("Run() = %q; want %q", output.Text(), "hello world")
+	}
+	if len(deltas) != 2 || deltas[0] != "hello" || deltas[1] != " world" {
+		t.Fatalf("callback deltas = %q; want [hello  world]", deltas)
+	}
+}
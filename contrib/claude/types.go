@@ -66,6 +66,13 @@ func convertClaudeToBlades(message *anthropic.Message) (*blades.ModelResponse, e
 			})
 		}
 	}
+	if total := message.Usage.InputTokens + message.Usage.OutputTokens; total > 0 {
+		msg.Usage = &blades.Usage{
+			PromptTokens:     int(message.Usage.InputTokens),
+			CompletionTokens: int(message.Usage.OutputTokens),
+			TotalTokens:      int(total),
+		}
+	}
 
 	return &blades.ModelResponse{
 		Message: msg,
@@ -0,0 +1,51 @@
+package claude
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/go-kratos/blades"
+)
+
+func TestConvertClaudeToBladesUsage(t *testing.T) {
+	tests := []struct {
+		name  string
+		usage string
+		want  *blades.Usage
+	}{
+		{
+			name:  "with usage",
+			usage: `{"input_tokens":20,"output_tokens":7}`,
+			want:  &blades.Usage{PromptTokens: 20, CompletionTokens: 7, TotalTokens: 27},
+		},
+		{
+			name:  "zero usage",
+			usage: `{"input_tokens":0,"output_tokens":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var message anthropic.Message
+			raw := `{"id":"msg_1","type":"message","role":"assistant","model":"claude-test",` +
+				`"content":[{"type":"text","text":"hello"}],"stop_reason":"end_turn","usage":` + tt.usage + `}`
+			if err := json.Unmarshal([]byte(raw), &message); err != nil {
+				t.Fatalf("unmarshal message: %v", err)
+			}
+			res, err := convertClaudeToBlades(&message)
+			if err != nil {
+				t.Fatalf("convertClaudeToBlades() error: %v", err)
+			}
+			if got := res.Message.Text(); got != "hello" {
+				t.Errorf("text = %q; want %q", got, "hello")
+			}
+			got := res.Message.Usage
+			switch {
+			case tt.want == nil && got != nil:
+				t.Fatalf("Usage = %+v; want nil", got)
+			case tt.want != nil && (got == nil || *got != *tt.want):
+				t.Fatalf("Usage = %+v; want %+v", got, tt.want)
+			}
+		})
+	}
+}
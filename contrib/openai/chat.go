@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"errors"
 	"log"
+	"net/http"
+	"os"
+	"strings"
 
 	"github.com/go-kratos/blades"
 	"github.com/go-kratos/blades/tools"
@@ -30,6 +33,14 @@ func WithReasoningEffort(effort shared.ReasoningEffort) ChatOption {
 	}
 }
 
+// WithStreamUsage requests token usage in the final chunk of streamed responses
+// (stream_options.include_usage), so the final streamed message reports Usage.
+func WithStreamUsage() ChatOption {
+	return func(o *ChatOptions) {
+		o.StreamUsage = true
+	}
+}
+
 // WithChatOptions sets request options for chat completions.
 func WithChatOptions(opts ...option.RequestOption) ChatOption {
 	return func(o *ChatOptions) {
@@ -37,9 +48,67 @@ func WithChatOptions(opts ...option.RequestOption) ChatOption {
 	}
 }
 
+// WithBaseURL sets the API base URL, e.g. http://localhost:11434/v1 for a local
+// OpenAI-compatible server. It takes precedence over OPENAI_BASE_URL.
+func WithBaseURL(baseURL string) ChatOption {
+	return func(o *ChatOptions) {
+		o.BaseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests, e.g. to configure timeouts or proxies.
+func WithHTTPClient(client *http.Client) ChatOption {
+	return func(o *ChatOptions) {
+		o.HTTPClient = client
+	}
+}
+
+// WithAzure routes requests to an Azure OpenAI deployment at
+// {endpoint}/openai/deployments/{deployment}/chat/completions?api-version={apiVersion}.
+// The API key is sent in the api-key header instead of bearer auth; if apiKey is
+// empty it is read from the AZURE_OPENAI_API_KEY environment variable.
+func WithAzure(endpoint, deployment, apiVersion, apiKey string) ChatOption {
+	return func(o *ChatOptions) {
+		o.Azure = &AzureOptions{
+			Endpoint:   endpoint,
+			Deployment: deployment,
+			APIVersion: apiVersion,
+			APIKey:     apiKey,
+		}
+	}
+}
+
+// AzureOptions holds the Azure OpenAI routing configuration.
+type AzureOptions struct {
+	Endpoint   string
+	Deployment string
+	APIVersion string
+	APIKey     string
+}
+
+// requestOptions returns the client options that target the Azure deployment.
+func (o *AzureOptions) requestOptions() []option.RequestOption {
+	baseURL := strings.TrimSuffix(o.Endpoint, "/") + "/openai/deployments/" + o.Deployment + "/"
+	apiKey := o.APIKey
+	if apiKey == "" {
+		apiKey = os.Getenv("AZURE_OPENAI_API_KEY")
+	}
+	return []option.RequestOption{
+		option.WithBaseURL(baseURL),
+		option.WithQuery("api-version", o.APIVersion),
+		option.WithHeaderDel("authorization"),
+		option.WithHeader("api-key", apiKey),
+	}
+}
+
 type ChatOptions struct {
 	ReasoningEffort shared.ReasoningEffort
+	StreamUsage     bool
 	RequestOpts     []option.RequestOption
+	BaseURL         string
+	HTTPClient      *http.Client
+	Azure           *AzureOptions
+	Retry           *RetryOptions
 }
 
 // ChatProvider implements blades.ModelProvider for OpenAI-compatible chat models.
@@ -49,16 +118,30 @@ type ChatProvider struct {
 }
 
 // NewChatProvider constructs an OpenAI provider. The API key is read from
-// the OPENAI_API_KEY environment variable. If OPENAI_BASE_URL is set,
-// it is used as the API base URL; otherwise the library default is used.
+// the OPENAI_API_KEY environment variable. The API base URL is taken from
+// WithBaseURL, then OPENAI_BASE_URL; otherwise the library default is used.
 func NewChatProvider(opts ...ChatOption) blades.ModelProvider {
 	chatOpts := ChatOptions{}
 	for _, opt := range opts {
 		opt(&chatOpts)
 	}
+	var requestOpts []option.RequestOption
+	if chatOpts.BaseURL != "" {
+		requestOpts = append(requestOpts, option.WithBaseURL(chatOpts.BaseURL))
+	}
+	if chatOpts.HTTPClient != nil {
+		requestOpts = append(requestOpts, option.WithHTTPClient(chatOpts.HTTPClient))
+	}
+	if chatOpts.Azure != nil {
+		requestOpts = append(requestOpts, chatOpts.Azure.requestOptions()...)
+	}
+	if chatOpts.Retry != nil {
+		requestOpts = append(requestOpts, chatOpts.Retry.requestOptions()...)
+	}
+	requestOpts = append(requestOpts, chatOpts.RequestOpts...)
 	return &ChatProvider{
 		opts:   chatOpts,
-		client: openai.NewClient(chatOpts.RequestOpts...),
+		client: openai.NewClient(requestOpts...),
 	}
 }
 
@@ -80,6 +163,7 @@ func (p *ChatProvider) Generate(ctx context.Context, req *blades.ModelRequest, o
 	if err != nil {
 		return nil, err
 	}
+	res.Message.Usage = toUsage(chatResponse.Usage)
 	return res, nil
 }
 
@@ -94,6 +178,9 @@ func (p *ChatProvider) NewStream(ctx context.Context, req *blades.ModelRequest,
 	if err != nil {
 		return nil, err
 	}
+	if p.opts.StreamUsage {
+		params.StreamOptions.IncludeUsage = param.NewOpt(true)
+	}
 	pipe := blades.NewStreamPipe[*blades.ModelResponse]()
 	pipe.Go(func() error {
 		stream := p.client.Chat.Completions.NewStreaming(ctx, params)
@@ -112,12 +199,22 @@ func (p *ChatProvider) NewStream(ctx context.Context, req *blades.ModelRequest,
 		if err != nil {
 			return err
 		}
+		finalResponse.Message.Usage = toUsage(acc.ChatCompletion.Usage)
 		pipe.Send(finalResponse)
 		return nil
 	})
 	return pipe, nil
 }
 
+// model returns the model name for the request. Azure deployments route by
+// deployment name, so it takes the place of the requested model.
+func (p *ChatProvider) model(req *blades.ModelRequest) string {
+	if p.opts.Azure != nil && p.opts.Azure.Deployment != "" {
+		return p.opts.Azure.Deployment
+	}
+	return req.Model
+}
+
 // toChatCompletionParams converts a generic model request into OpenAI params.
 func (p *ChatProvider) toChatCompletionParams(req *blades.ModelRequest, opt blades.ModelOptions) (openai.ChatCompletionNewParams, error) {
 	tools, err := toTools(req.Tools)
@@ -126,7 +223,7 @@ func (p *ChatProvider) toChatCompletionParams(req *blades.ModelRequest, opt blad
 	}
 	params := openai.ChatCompletionNewParams{
 		Tools:           tools,
-		Model:           req.Model,
+		Model:           p.model(req),
 		ReasoningEffort: p.opts.ReasoningEffort,
 		Messages:        make([]openai.ChatCompletionMessageParamUnion, 0, len(req.Messages)),
 	}
@@ -167,6 +264,11 @@ func (p *ChatProvider) toChatCompletionParams(req *blades.ModelRequest, opt blad
 			OfJSONSchema: &openai.ResponseFormatJSONSchemaParam{JSONSchema: schemaParam},
 		}
 	}
+	if req.OutputSchema == nil && req.ResponseFormat != nil && req.ResponseFormat.Type == blades.ResponseFormatJSONObject {
+		params.ResponseFormat = openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONObject: &shared.ResponseFormatJSONObjectParam{},
+		}
+	}
 	for _, msg := range req.Messages {
 		switch msg.Role {
 		case blades.RoleUser:
@@ -366,6 +468,18 @@ func choiceToResponse(ctx context.Context, params openai.ChatCompletionNewParams
 	return &blades.ModelResponse{Message: msg}, nil
 }
 
+// toUsage converts the API usage object, returning nil when no usage was reported.
+func toUsage(usage openai.CompletionUsage) *blades.Usage {
+	if usage.TotalTokens == 0 {
+		return nil
+	}
+	return &blades.Usage{
+		PromptTokens:     int(usage.PromptTokens),
+		CompletionTokens: int(usage.CompletionTokens),
+		TotalTokens:      int(usage.TotalTokens),
+	}
+}
+
 // chunkChoiceToResponse converts a streaming chunk choice to a ModelResponse.
 func chunkChoiceToResponse(ctx context.Context, choices []openai.ChatCompletionChunkChoice) (*blades.ModelResponse, error) {
 	msg := &blades.Message{
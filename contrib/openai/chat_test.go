@@ -0,0 +1,126 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kratos/blades"
+)
+
+func TestWithAzure(t *testing.T) {
+	tests := []struct {
+		name    string
+		apiKey  string
+		envKey  string
+		wantKey string
+	}{
+		{name: "explicit key", apiKey: "azure-key", envKey: "env-key", wantKey: "azure-key"},
+		{name: "environment key", envKey: "env-key", wantKey: "env-key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Clone(context.Background())
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(chatCompletionJSON))
+			}))
+			defer srv.Close()
+			t.Setenv("OPENAI_API_KEY", "openai-key")
+			t.Setenv("AZURE_OPENAI_API_KEY", tt.envKey)
+
+			provider := NewChatProvider(WithAzure(srv.URL+"/", "my-deployment", "2024-06-01", tt.apiKey))
+			req := &blades.ModelRequest{Model: "ignored", Messages: []*blades.Message{blades.UserMessage("hi")}}
+			if _, err := provider.Generate(context.Background(), req); err != nil {
+				t.Fatalf("Generate() error: %v", err)
+			}
+			if want := "/openai/deployments/my-deployment/chat/completions"; got.URL.Path != want {
+				t.Errorf("path = %q; want %q", got.URL.Path, want)
+			}
+			if v := got.URL.Query().Get("api-version"); v != "2024-06-01" {
+				t.Errorf("api-version = %q; want %q", v, "2024-06-01")
+			}
+			if v := got.Header.Get("api-key"); v != tt.wantKey {
+				t.Errorf("api-key header = %q; want %q", v, tt.wantKey)
+			}
+			if v := got.Header.Get("Authorization"); v != "" {
+				t.Errorf("Authorization header = %q; want none", v)
+			}
+		})
+	}
+}
+
+func TestChatUsage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"chatcmpl-1","object":"chat.completion","created":0,"model":"gpt-test",` +
+			`"choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"hello"}}],` +
+			`"usage":{"prompt_tokens":12,"completion_tokens":3,"total_tokens":15}}`))
+	}))
+	defer srv.Close()
+	t.Setenv("OPENAI_API_KEY", "test-key")
+
+	provider := NewChatProvider(WithBaseURL(srv.URL))
+	req := &blades.ModelRequest{Model: "gpt-test", Messages: []*blades.Message{blades.UserMessage("hi")}}
+	res, err := provider.Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	want := blades.Usage{PromptTokens: 12, CompletionTokens: 3, TotalTokens: 15}
+	if res.Message.Usage == nil || *res.Message.Usage != want {
+		t.Fatalf("Usage = %+v; want %+v", res.Message.Usage, want)
+	}
+}
+
+func TestChatStreamUsage(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		chunk := `{"id":"chatcmpl-1","object":"chat.completion.chunk","created":0,"model":"gpt-test","choices":%s%s}`
+		for _, event := range []string{
+			fmt.Sprintf(chunk, `[{"index":0,"delta":{"role":"assistant","content":"hel"}}]`, ""),
+			fmt.Sprintf(chunk, `[{"index":0,"delta":{"content":"lo"},"finish_reason":"stop"}]`, ""),
+			fmt.Sprintf(chunk, `[]`, `,"usage":{"prompt_tokens":12,"completion_tokens":3,"total_tokens":15}`),
+			"[DONE]",
+		} {
+			fmt.Fprintf(w, "data: %s\n\n", event)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("OPENAI_API_KEY", "test-key")
+
+	provider := NewChatProvider(WithBaseURL(srv.URL), WithStreamUsage())
+	req := &blades.ModelRequest{Model: "gpt-test", Messages: []*blades.Message{blades.UserMessage("hi")}}
+	stream, err := provider.NewStream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("NewStream() error: %v", err)
+	}
+	var last *blades.ModelResponse
+	for stream.Next() {
+		res, err := stream.Current()
+		if err != nil {
+			t.Fatalf("stream error: %v", err)
+		}
+		last = res
+	}
+	if _, err := stream.Current(); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+	if opts, _ := body["stream_options"].(map[string]any); opts["include_usage"] != true {
+		t.Errorf("stream_options = %v; want include_usage true", body["stream_options"])
+	}
+	if last == nil || last.Message.Text() != "hello" {
+		t.Fatalf("final message = %+v; want text %q", last, "hello")
+	}
+	want := blades.Usage{PromptTokens: 12, CompletionTokens: 3, TotalTokens: 15}
+	if last.Message.Usage == nil || *last.Message.Usage != want {
+		t.Fatalf("final Usage = %+v; want %+v", last.Message.Usage, want)
+	}
+}
@@ -165,7 +165,7 @@ func toImageResponse(res *openai.ImagesResponse) (*blades.ModelResponse, error)
 			})
 		}
 		if img.RevisedPrompt != "" {
-			key := fmt.Sprintf("%s_revised_prompt_%d", name)
+			key := name + "_revised_prompt"
 			message.Metadata[key] = img.RevisedPrompt
 		}
 	}
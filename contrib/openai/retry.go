@@ -0,0 +1,118 @@
+package openai
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/openai/openai-go/v2/option"
+)
+
+// maxRetryDelay caps the delay between attempts, whether it comes from backoff or Retry-After.
+const maxRetryDelay = 30 * time.Second
+
+// WithRetry retries chat requests that fail with 429, 500, 502, 503 or 504, up to
+// maxAttempts attempts in total. The delay honors the Retry-After header when present
+// and otherwise backs off exponentially from baseBackoff with jitter; either way it is
+// capped at 30 seconds. The client's built-in retries are disabled so that this policy
+// is the only one in effect.
+func WithRetry(maxAttempts int, baseBackoff time.Duration) ChatOption {
+	return func(o *ChatOptions) {
+		o.Retry = &RetryOptions{
+			MaxAttempts: maxAttempts,
+			BaseBackoff: baseBackoff,
+		}
+	}
+}
+
+// RetryOptions holds the retry policy for chat requests.
+type RetryOptions struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+}
+
+// requestOptions returns the client options that apply the retry policy.
+func (o *RetryOptions) requestOptions() []option.RequestOption {
+	return []option.RequestOption{
+		option.WithMaxRetries(0),
+		option.WithMiddleware(o.middleware),
+	}
+}
+
+func (o *RetryOptions) middleware(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		res, err := next(req)
+		if err != nil || attempt >= o.MaxAttempts || !retryableStatus(res.StatusCode) {
+			return res, err
+		}
+		delay := o.delay(attempt, res)
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns how long to wait after the given failed attempt: the Retry-After
+// value when present, otherwise the backoff, capped at maxRetryDelay.
+func (o *RetryOptions) delay(attempt int, res *http.Response) time.Duration {
+	d := retryAfter(res)
+	if d <= 0 {
+		d = o.backoff(attempt)
+	}
+	return min(d, maxRetryDelay)
+}
+
+// backoff returns the exponential delay before the next attempt, jittered into [d/2, d].
+// Doubling stops at maxRetryDelay, so large attempt counts cannot overflow.
+func (o *RetryOptions) backoff(attempt int) time.Duration {
+	if o.BaseBackoff <= 0 {
+		return 0
+	}
+	d := o.BaseBackoff
+	for i := 1; i < attempt && d < maxRetryDelay; i++ {
+		d *= 2
+	}
+	d = min(d, maxRetryDelay)
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses the Retry-After header as either seconds or an HTTP date.
+func retryAfter(res *http.Response) time.Duration {
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
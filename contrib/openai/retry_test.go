@@ -0,0 +1,113 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/blades"
+)
+
+const chatCompletionJSON = `{"id":"chatcmpl-1","object":"chat.completion","created":0,"model":"gpt-test",` +
+	`"choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"hello"}}]}`
+
+// flakyServer fails the first failures requests with status and then succeeds.
+func flakyServer(t *testing.T, failures int32, status int, retryAfter string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(chatCompletionJSON))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func generate(t *testing.T, srv *httptest.Server, opts ...ChatOption) (*blades.ModelResponse, error) {
+	t.Helper()
+	t.Setenv("OPENAI_API_KEY", "test-key")
+	provider := NewChatProvider(append([]ChatOption{WithBaseURL(srv.URL)}, opts...)...)
+	req := &blades.ModelRequest{Model: "gpt-test", Messages: []*blades.Message{blades.UserMessage("hi")}}
+	return provider.Generate(context.Background(), req)
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		failures  int32
+		wantCalls int32
+		wantErr   bool
+	}{
+		{name: "429 then success", status: http.StatusTooManyRequests, failures: 2, wantCalls: 3},
+		{name: "503 then success", status: http.StatusServiceUnavailable, failures: 1, wantCalls: 2},
+		{name: "stops at max attempts", status: http.StatusServiceUnavailable, failures: 10, wantCalls: 3, wantErr: true},
+		{name: "not retryable", status: http.StatusBadRequest, failures: 1, wantCalls: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := flakyServer(t, tt.failures, tt.status, "")
+			res, err := generate(t, srv, WithRetry(3, time.Millisecond))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+			} else if err != nil {
+				t.Fatalf("Generate() error: %v", err)
+			} else if res.Message.Text() != "hello" {
+				t.Fatalf("Generate() = %q; want %q", res.Message.Text(), "hello")
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Fatalf("server received %d requests; want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	srv, calls := flakyServer(t, 1, http.StatusTooManyRequests, "1")
+	start := time.Now()
+	// The base backoff alone would retry almost immediately.
+	if _, err := generate(t, srv, WithRetry(2, time.Millisecond)); err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("retried after %s; want Retry-After of 1s honored", elapsed)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("server received %d requests; want 2", got)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	withRetryAfter := func(v string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{v}}}
+	}
+	o := &RetryOptions{MaxAttempts: 100, BaseBackoff: time.Second}
+	if got := o.delay(1, withRetryAfter("5")); got != 5*time.Second {
+		t.Errorf("delay with Retry-After 5 = %s; want 5s", got)
+	}
+	if got := o.delay(1, withRetryAfter(strconv.Itoa(24*3600))); got != maxRetryDelay {
+		t.Errorf("delay with a day of Retry-After = %s; want %s", got, maxRetryDelay)
+	}
+	for _, attempt := range []int{1, 10, 64, 1000} {
+		got := o.delay(attempt, &http.Response{})
+		if got <= 0 || got > maxRetryDelay {
+			t.Errorf("backoff for attempt %d = %s; want in (0, %s]", attempt, got, maxRetryDelay)
+		}
+	}
+	if got := o.delay(1, &http.Response{}); got < 500*time.Millisecond || got > time.Second {
+		t.Errorf("backoff for attempt 1 = %s; want in [500ms, 1s]", got)
+	}
+}
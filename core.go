@@ -2,6 +2,7 @@ package blades
 
 import (
 	"context"
+	"slices"
 	"strings"
 )
 
@@ -35,6 +36,37 @@ func (p *Prompt) String() string {
 	return strings.TrimSuffix(buf.String(), "\n")
 }
 
+// Append returns a new prompt with the messages added after the existing ones.
+func (p *Prompt) Append(messages ...*Message) *Prompt {
+	return NewPrompt(slices.Concat(p.Messages, messages)...)
+}
+
+// Prepend returns a new prompt with the messages added before the existing ones.
+func (p *Prompt) Prepend(messages ...*Message) *Prompt {
+	return NewPrompt(slices.Concat(messages, p.Messages)...)
+}
+
+// WithoutRole returns a new prompt without the messages of the given role.
+func (p *Prompt) WithoutRole(role Role) *Prompt {
+	messages := make([]*Message, 0, len(p.Messages))
+	for _, m := range p.Messages {
+		if m.Role != role {
+			messages = append(messages, m)
+		}
+	}
+	return NewPrompt(messages...)
+}
+
+// LastOf returns the most recent message with the given role, or nil if there is none.
+func (p *Prompt) LastOf(role Role) *Message {
+	for i := len(p.Messages) - 1; i >= 0; i-- {
+		if p.Messages[i].Role == role {
+			return p.Messages[i]
+		}
+	}
+	return nil
+}
+
 // Streamable yields a sequence of assistant responses until completion.
 type Streamable[T any] interface {
 	Next() bool
@@ -0,0 +1,57 @@
+package blades
+
+import "testing"
+
+func promptTexts(p *Prompt) []string {
+	texts := make([]string, 0, len(p.Messages))
+	for _, m := range p.Messages {
+		texts = append(texts, m.Text())
+	}
+	return texts
+}
+
+func TestPromptHelpers(t *testing.T) {
+	// The backing array has spare capacity, so an in-place append would alias it.
+	messages := make([]*Message, 0, 8)
+	messages = append(messages, SystemMessage("system"), UserMessage("u1"), AssistantMessage("a1"), UserMessage("u2"))
+	prompt := NewPrompt(messages...)
+
+	tests := []struct {
+		name string
+		got  *Prompt
+		want []string
+	}{
+		{name: "append", got: prompt.Append(AssistantMessage("a2")), want: []string{"system", "u1", "a1", "u2", "a2"}},
+		{name: "prepend", got: prompt.Prepend(SystemMessage("first")), want: []string{"first", "system", "u1", "a1", "u2"}},
+		{name: "without role", got: prompt.WithoutRole(RoleUser), want: []string{"system", "a1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := promptTexts(tt.got)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %q; want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %q; want %q", got, tt.want)
+				}
+			}
+		})
+	}
+
+	a := prompt.Append(AssistantMessage("x"))
+	b := prompt.Append(AssistantMessage("y"))
+	if a.Latest().Text() != "x" || b.Latest().Text() != "y" {
+		t.Fatalf("appended prompts share a backing array: %q, %q", promptTexts(a), promptTexts(b))
+	}
+	if got := promptTexts(prompt); len(got) != 4 || got[3] != "u2" {
+		t.Fatalf("original prompt was modified: %q", got)
+	}
+
+	if got := prompt.LastOf(RoleUser); got == nil || got.Text() != "u2" {
+		t.Errorf("LastOf(RoleUser) = %v; want u2", got)
+	}
+	if got := prompt.LastOf(RoleTool); got != nil {
+		t.Errorf("LastOf(RoleTool) = %v; want nil", got)
+	}
+}
@@ -9,4 +9,6 @@ var (
     ErrMissingFinalResponse = errors.New("stream ended without a final response")
     // ErrConfirmationDenied is returned when confirmation middleware denies execution.
     ErrConfirmationDenied = errors.New("confirmation denied")
+    // ErrTimeout is returned when timeout middleware cancels a run that exceeded its duration.
+    ErrTimeout = errors.New("run timed out")
 )
@@ -2,11 +2,26 @@ package flow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/go-kratos/blades"
 )
 
+// ErrNoBranchSelected is returned when a MultiBranch condition selects no runners.
+var ErrNoBranchSelected = errors.New("branch: no runner selected")
+
+// BranchOption defines a function type for configuring Branch instances.
+type BranchOption func(*Branch)
+
+// WithBranchDefault sets the runner used when the condition selects a name
+// that has no registered runner, instead of returning an error.
+func WithBranchDefault(runner blades.Runnable) BranchOption {
+	return func(b *Branch) {
+		b.fallback = runner
+	}
+}
+
 // BranchCondition is a function that selects a branch name based on the context.
 type BranchCondition func(ctx context.Context, input *blades.Prompt) (string, error)
 
@@ -14,14 +29,19 @@ type BranchCondition func(ctx context.Context, input *blades.Prompt) (string, er
 type Branch struct {
 	condition BranchCondition
 	runners   map[string]blades.Runnable
+	fallback  blades.Runnable
 }
 
-// NewBranch creates a new Branch with the given selector and runners.
-func NewBranch(condition BranchCondition, runners map[string]blades.Runnable) *Branch {
-	return &Branch{
+// NewBranch creates a new Branch with the given selector, runners, and options.
+func NewBranch(condition BranchCondition, runners map[string]blades.Runnable, opts ...BranchOption) *Branch {
+	b := &Branch{
 		condition: condition,
 		runners:   runners,
 	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
 }
 
 // Run executes the selected runner based on the selector function.
@@ -36,7 +56,10 @@ func (c *Branch) Run(ctx context.Context, input *blades.Prompt, opts ...blades.M
 	}
 	runner, ok := c.runners[name]
 	if !ok {
-		return output, fmt.Errorf("branch: runner not found: %s", name)
+		if c.fallback == nil {
+			return output, fmt.Errorf("branch: runner not found: %s", name)
+		}
+		runner = c.fallback
 	}
 	return runner.Run(ctx, input, opts...)
 }
@@ -54,3 +77,61 @@ func (c *Branch) RunStream(ctx context.Context, input *blades.Prompt, opts ...bl
 	})
 	return pipe, nil
 }
+
+// MultiBranchCondition is a function that selects the names of the branches to run based on the context.
+type MultiBranchCondition func(ctx context.Context, input *blades.Prompt) ([]string, error)
+
+// MultiBranch runs every runner selected by its condition concurrently and merges their outputs.
+type MultiBranch struct {
+	condition MultiBranchCondition
+	runners   map[string]blades.Runnable
+	merger    ParallelMerger
+}
+
+// NewMultiBranch creates a new MultiBranch with the given condition, runners, and merger.
+// If merger is nil, the outputs are merged as in NewParallel.
+func NewMultiBranch(condition MultiBranchCondition, runners map[string]blades.Runnable, merger ParallelMerger) *MultiBranch {
+	return &MultiBranch{
+		condition: condition,
+		runners:   runners,
+		merger:    merger,
+	}
+}
+
+// Run executes all selected runners concurrently and merges their outputs in selection order.
+func (c *MultiBranch) Run(ctx context.Context, input *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
+	names, err := c.condition(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, ErrNoBranchSelected
+	}
+	runners := make([]blades.Runnable, 0, len(names))
+	for _, name := range names {
+		runner, ok := c.runners[name]
+		if !ok {
+			return nil, fmt.Errorf("branch: runner not found: %s", name)
+		}
+		runners = append(runners, runner)
+	}
+	var parallelOpts []ParallelOption
+	if c.merger != nil {
+		parallelOpts = append(parallelOpts, WithParallelMerger(c.merger))
+	}
+	return NewParallel(runners, parallelOpts...).Run(ctx, input, opts...)
+}
+
+// RunStream executes the selected runners and streams the merged output.
+func (c *MultiBranch) RunStream(ctx context.Context, input *blades.Prompt, opts ...blades.ModelOption) (blades.Streamable[*blades.Message], error) {
+	pipe := blades.NewStreamPipe[*blades.Message]()
+	pipe.Go(func() error {
+		output, err := c.Run(ctx, input, opts...)
+		if err != nil {
+			return err
+		}
+		pipe.Send(output)
+		return nil
+	})
+	return pipe, nil
+}
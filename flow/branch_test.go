@@ -0,0 +1,83 @@
+package flow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/blades"
+)
+
+func TestBranchDefault(t *testing.T) {
+	condition := func(ctx context.Context, input *blades.Prompt) (string, error) {
+		return input.Latest().Text(), nil
+	}
+	runners := map[string]blades.Runnable{"code": textRunner("code")}
+	tests := []struct {
+		name    string
+		opts    []BranchOption
+		label   string
+		want    string
+		wantErr string
+	}{
+		{name: "registered", label: "code", want: "code"},
+		{name: "unregistered", label: "poetry", wantErr: "runner not found: poetry"},
+		{name: "default", opts: []BranchOption{WithBranchDefault(textRunner("general"))}, label: "poetry", want: "general"},
+		{name: "default not used", opts: []BranchOption{WithBranchDefault(textRunner("general"))}, label: "code", want: "code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			branch := NewBranch(condition, runners, tt.opts...)
+			output, err := branch.Run(context.Background(), blades.NewPrompt(blades.UserMessage(tt.label)))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if output.Text() != tt.want {
+				t.Fatalf("Run() = %q; want %q", output.Text(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiBranch(t *testing.T) {
+	runners := map[string]blades.Runnable{
+		"code": textRunner("code results"),
+		"web":  textRunner("web results"),
+		"docs": textRunner("docs results"),
+	}
+	selectNames := func(names ...string) MultiBranchCondition {
+		return func(ctx context.Context, input *blades.Prompt) ([]string, error) {
+			return names, nil
+		}
+	}
+	joinTexts := func(ctx context.Context, outputs []*blades.Message) (*blades.Message, error) {
+		texts := make([]string, 0, len(outputs))
+		for _, output := range outputs {
+			texts = append(texts, output.Text())
+		}
+		return blades.AssistantMessage(strings.Join(texts, " | ")), nil
+	}
+	prompt := blades.NewPrompt(blades.UserMessage("query"))
+
+	output, err := NewMultiBranch(selectNames("web", "code"), runners, joinTexts).Run(context.Background(), prompt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "web results | code results"; output.Text() != want {
+		t.Fatalf("Run() = %q; want %q", output.Text(), want)
+	}
+
+	if _, err := NewMultiBranch(selectNames(), runners, joinTexts).Run(context.Background(), prompt); !errors.Is(err, ErrNoBranchSelected) {
+		t.Fatalf("expected ErrNoBranchSelected, got %v", err)
+	}
+	if _, err := NewMultiBranch(selectNames("code", "missing"), runners, nil).Run(context.Background(), prompt); err == nil || !strings.Contains(err.Error(), "runner not found: missing") {
+		t.Fatalf("expected runner not found error, got %v", err)
+	}
+}
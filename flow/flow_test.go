@@ -0,0 +1,16 @@
+package flow
+
+import (
+	"context"
+
+	"github.com/go-kratos/blades"
+)
+
+// textRunner returns a Runnable that replies with the given text.
+func textRunner(text string) blades.Runnable {
+	return &blades.HandleFunc{
+		Handle: func(ctx context.Context, p *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
+			return blades.AssistantMessage(text), nil
+		},
+	}
+}
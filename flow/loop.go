@@ -2,6 +2,7 @@ package flow
 
 import (
 	"context"
+	"slices"
 
 	"github.com/go-kratos/blades"
 )
@@ -16,18 +17,65 @@ func WithLoopMaxIterations(n int) LoopOption {
 	}
 }
 
+// WithLoopCollectHistory records the output of every iteration in the state of the
+// session carried by the run context, under LoopHistoryKey(name), retrievable with
+// LoopHistory. Give nested or sibling loops distinct names so their histories do not
+// collide. Loops run without a session record nothing.
+func WithLoopCollectHistory(name string) LoopOption {
+	return func(l *Loop) {
+		l.collectHistory = true
+		l.historyKey = LoopHistoryKey(name)
+	}
+}
+
+// LoopHistoryKey returns the session state key under which the Loop with the given
+// history name stores its iteration outputs.
+func LoopHistoryKey(name string) string {
+	return "loop_history:" + name
+}
+
+// LoopHistory returns the iteration outputs recorded by the Loop configured with
+// WithLoopCollectHistory(name), in iteration order. The context must carry the session
+// the loop ran with (see blades.NewSessionContext).
+func LoopHistory(ctx context.Context, name string) []*blades.Message {
+	session, ok := blades.FromSessionContext(ctx)
+	if !ok {
+		return nil
+	}
+	value, ok := session.State.Load(LoopHistoryKey(name))
+	if !ok {
+		return nil
+	}
+	history, _ := value.([]*blades.Message)
+	return history
+}
+
 // LoopCondition defines a function type for evaluating the loop condition.
 type LoopCondition func(ctx context.Context, output *blades.Message) (bool, error)
 
+// LoopStateCondition defines a function type for evaluating the loop condition with the
+// number of completed iterations and the output of the latest one.
+type LoopStateCondition func(ctx context.Context, iteration int, output *blades.Message) (bool, error)
+
 // Loop represents a looping construct that repeatedly executes a runner until a condition is met.
 type Loop struct {
-	maxIterations int
-	condition     LoopCondition
-	runner        blades.Runnable
+	maxIterations  int
+	collectHistory bool
+	historyKey     string
+	condition      LoopStateCondition
+	runner         blades.Runnable
 }
 
 // NewLoop creates a new Loop instance with the specified condition, runner, and options.
 func NewLoop(condition LoopCondition, runner blades.Runnable, opts ...LoopOption) *Loop {
+	return NewLoopWithState(func(ctx context.Context, _ int, output *blades.Message) (bool, error) {
+		return condition(ctx, output)
+	}, runner, opts...)
+}
+
+// NewLoopWithState creates a new Loop instance whose condition also receives the number of
+// completed iterations, starting at 1 after the first run.
+func NewLoopWithState(condition LoopStateCondition, runner blades.Runnable, opts ...LoopOption) *Loop {
 	l := &Loop{
 		condition:     condition,
 		runner:        runner,
@@ -42,14 +90,20 @@ func NewLoop(condition LoopCondition, runner blades.Runnable, opts ...LoopOption
 // Run executes the Loop, repeatedly running the runner until the condition is met or an error occurs.
 func (l *Loop) Run(ctx context.Context, input *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
 	var (
-		err    error
-		output *blades.Message
+		err     error
+		output  *blades.Message
+		history []*blades.Message
 	)
+	session, hasSession := blades.FromSessionContext(ctx)
 	for i := 0; i < l.maxIterations; i++ {
 		if output, err = l.runner.Run(ctx, input, opts...); err != nil {
 			return output, err
 		}
-		ok, err := l.condition(ctx, output)
+		if l.collectHistory && hasSession {
+			history = append(history, output)
+			session.PutState(l.historyKey, slices.Clone(history))
+		}
+		ok, err := l.condition(ctx, i+1, output)
 		if err != nil {
 			return output, err
 		}
@@ -0,0 +1,132 @@
+package flow
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-kratos/blades"
+)
+
+// countingRunner replies with "draft N" on its Nth run and "DONE" from the given run on.
+func countingRunner(doneAt int) blades.Runnable {
+	var n int
+	return &blades.HandleFunc{
+		Handle: func(ctx context.Context, p *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
+			n++
+			if n >= doneAt {
+				return blades.AssistantMessage("DONE"), nil
+			}
+			return blades.AssistantMessage(fmt.Sprintf("draft %d", n)), nil
+		},
+	}
+}
+
+func TestLoopWithState(t *testing.T) {
+	var iterations []int
+	loop := NewLoopWithState(func(ctx context.Context, iteration int, output *blades.Message) (bool, error) {
+		iterations = append(iterations, iteration)
+		return output.Text() != "DONE", nil
+	}, countingRunner(3), WithLoopMaxIterations(5))
+	output, err := loop.Run(context.Background(), blades.NewPrompt(blades.UserMessage("write")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.Text() != "DONE" {
+		t.Fatalf("Run() = %q; want %q", output.Text(), "DONE")
+	}
+	if fmt.Sprint(iterations) != "[1 2 3]" {
+		t.Fatalf("iterations = %v; want [1 2 3]", iterations)
+	}
+}
+
+func TestLoopMaxIterations(t *testing.T) {
+	loop := NewLoop(func(ctx context.Context, output *blades.Message) (bool, error) {
+		return true, nil
+	}, countingRunner(10), WithLoopMaxIterations(2))
+	output, err := loop.Run(context.Background(), blades.NewPrompt(blades.UserMessage("write")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.Text() != "draft 2" {
+		t.Fatalf("Run() = %q; want %q", output.Text(), "draft 2")
+	}
+}
+
+func TestLoopCollectHistory(t *testing.T) {
+	condition := func(ctx context.Context, output *blades.Message) (bool, error) {
+		return output.Text() != "DONE", nil
+	}
+	ctx := blades.NewSessionContext(context.Background(), blades.NewSession())
+	loop := NewLoop(condition, countingRunner(3), WithLoopCollectHistory("drafts"))
+	output, err := loop.Run(ctx, blades.NewPrompt(blades.UserMessage("write")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	history := LoopHistory(ctx, "drafts")
+	var texts []string
+	for _, m := range history {
+		texts = append(texts, m.Text())
+	}
+	if fmt.Sprint(texts) != "[draft 1 draft 2 DONE]" {
+		t.Fatalf("LoopHistory() = %q", texts)
+	}
+	if history[len(history)-1] != output {
+		t.Fatalf("last history entry is not the final output")
+	}
+
+	ctx = blades.NewSessionContext(context.Background(), blades.NewSession())
+	if _, err := NewLoop(condition, countingRunner(3)).Run(ctx, blades.NewPrompt()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if history := LoopHistory(ctx, "drafts"); history != nil {
+		t.Fatalf("expected no history by default, got %v", history)
+	}
+}
+
+func TestLoopCollectHistoryNested(t *testing.T) {
+	stop := func(ctx context.Context, output *blades.Message) (bool, error) {
+		return output.Text() != "DONE", nil
+	}
+	inner := NewLoop(stop, countingRunner(2), WithLoopCollectHistory("inner"))
+	outer := NewLoop(func(ctx context.Context, output *blades.Message) (bool, error) {
+		return true, nil
+	}, inner, WithLoopMaxIterations(2), WithLoopCollectHistory("outer"))
+	ctx := blades.NewSessionContext(context.Background(), blades.NewSession())
+	if _, err := outer.Run(ctx, blades.NewPrompt()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	texts := func(history []*blades.Message) string {
+		var texts []string
+		for _, m := range history {
+			texts = append(texts, m.Text())
+		}
+		return fmt.Sprint(texts)
+	}
+	// The inner loop's counter keeps running, so its second run finishes at once.
+	if got := texts(LoopHistory(ctx, "inner")); got != "[DONE]" {
+		t.Fatalf("inner history = %s; want [DONE]", got)
+	}
+	if got := texts(LoopHistory(ctx, "outer")); got != "[DONE DONE]" {
+		t.Fatalf("outer history = %s; want [DONE DONE]", got)
+	}
+}
+
+func TestLoopWithoutSession(t *testing.T) {
+	runner := &blades.HandleFunc{
+		Handle: func(ctx context.Context, p *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
+			if _, ok := blades.FromSessionContext(ctx); ok {
+				t.Error("loop created a session for its runner")
+			}
+			return blades.AssistantMessage("DONE"), nil
+		},
+	}
+	for _, opts := range [][]LoopOption{nil, {WithLoopCollectHistory("drafts")}} {
+		loop := NewLoop(func(ctx context.Context, output *blades.Message) (bool, error) {
+			return false, nil
+		}, runner, opts...)
+		if _, err := loop.Run(context.Background(), blades.NewPrompt()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package flow
 
 import (
 	"context"
+	"maps"
+	"strconv"
+	"sync"
 
 	"github.com/go-kratos/blades"
 	"golang.org/x/sync/errgroup"
@@ -17,13 +20,38 @@ func WithParallelMerger(merger ParallelMerger) ParallelOption {
 	}
 }
 
+// WithParallelOrdered makes RunStream emit runner outputs in declaration order
+// instead of completion order.
+func WithParallelOrdered() ParallelOption {
+	return func(p *Parallel) {
+		p.ordered = true
+	}
+}
+
+// ParallelRunnerKey is the message metadata key under which RunStream records the
+// name of the runner that produced each streamed output. Runners that implement
+// Name() string (such as blades.Agent) are identified by name, others by index.
+const ParallelRunnerKey = "runner"
+
 // ParallelMerger is a function that merges the outputs of multiple runners into a single output.
 type ParallelMerger func(ctx context.Context, outputs []*blades.Message) (*blades.Message, error)
 
+// ParallelResult holds the outcome of a single runner in a best-effort Parallel.
+type ParallelResult struct {
+	Output *blades.Message
+	Err    error
+}
+
+// ParallelResultMerger is a function that merges the per-runner results of a best-effort
+// Parallel into a single output. It decides whether partial failure is acceptable.
+type ParallelResultMerger func(ctx context.Context, results []ParallelResult) (*blades.Message, error)
+
 // Parallel represents a collection of Runnable runners that process input concurrently.
 type Parallel struct {
-	merger  ParallelMerger
-	runners []blades.Runnable
+	merger       ParallelMerger
+	resultMerger ParallelResultMerger
+	runners      []blades.Runnable
+	ordered      bool
 }
 
 // NewParallel creates a new Parallel with the given runners.
@@ -44,8 +72,21 @@ func NewParallel(runners []blades.Runnable, opts ...ParallelOption) *Parallel {
 	return p
 }
 
-// Run executes the chain of runners sequentially, passing the output of one as the input to the next.
+// NewParallelBestEffort creates a new Parallel that runs every runner to completion,
+// even if some fail, and passes each runner's output and error to the merger in index order.
+func NewParallelBestEffort(runners []blades.Runnable, merger ParallelResultMerger) *Parallel {
+	return &Parallel{
+		runners:      runners,
+		resultMerger: merger,
+	}
+}
+
+// Run executes the runners concurrently and merges their outputs.
+// The first error cancels the remaining runners unless the Parallel was created with NewParallelBestEffort.
 func (p *Parallel) Run(ctx context.Context, input *blades.Prompt, opts ...blades.ModelOption) (o *blades.Message, err error) {
+	if p.resultMerger != nil {
+		return p.runBestEffort(ctx, input, opts...)
+	}
 	var (
 		outputs = make([]*blades.Message, len(p.runners))
 	)
@@ -67,17 +108,114 @@ func (p *Parallel) Run(ctx context.Context, input *blades.Prompt, opts ...blades
 	return p.merger(ctx, outputs)
 }
 
-// RunStream executes the runners sequentially, streaming each output as it is produced.
-// Note: Although this method belongs to the Parallel struct, it runs runners one after another, not in parallel.
+// runBestEffort executes all runners concurrently without cancelling on error and merges their results.
+func (p *Parallel) runBestEffort(ctx context.Context, input *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
+	var (
+		wg      sync.WaitGroup
+		results = make([]ParallelResult, len(p.runners))
+	)
+	for idx, runner := range p.runners {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			output, err := runner.Run(ctx, input, opts...)
+			results[idx] = ParallelResult{Output: output, Err: err}
+		}()
+	}
+	wg.Wait()
+	return p.resultMerger(ctx, results)
+}
+
+// RunStream executes the runners concurrently and streams each runner's output as soon as it is
+// ready, tagged with ParallelRunnerKey in its metadata. Outputs are streamed in completion order,
+// or in declaration order with WithParallelOrdered. Once every runner has finished, the merged
+// output, as returned by Run, is emitted as the final message without a ParallelRunnerKey tag.
+// The first error cancels the remaining runners and ends the stream, after which Current reports
+// it. Cancelling ctx stops a stream whose consumer has stopped reading. A best-effort Parallel
+// streams only its merged output.
 func (p *Parallel) RunStream(ctx context.Context, input *blades.Prompt, opts ...blades.ModelOption) (blades.Streamable[*blades.Message], error) {
 	pipe := blades.NewStreamPipe[*blades.Message]()
+	if p.resultMerger != nil {
+		pipe.Go(func() error {
+			output, err := p.Run(ctx, input, opts...)
+			if err != nil {
+				return err
+			}
+			return pipe.SendContext(ctx, output)
+		})
+		return pipe, nil
+	}
 	pipe.Go(func() error {
-		output, err := p.Run(ctx, input, opts...)
+		type result struct {
+			idx    int
+			output *blades.Message
+		}
+		var (
+			waitErr error
+			// Buffered for every runner, so runners never block on a slow consumer.
+			results = make(chan result, len(p.runners))
+			outputs = make([]*blades.Message, len(p.runners))
+			pending = make([]*blades.Message, len(p.runners))
+			next    int
+		)
+		eg, egCtx := errgroup.WithContext(ctx)
+		for idx, runner := range p.runners {
+			eg.Go(func() error {
+				output, err := runner.Run(egCtx, input, opts...)
+				if err != nil {
+					return err
+				}
+				outputs[idx] = output
+				results <- result{idx: idx, output: tagRunner(output, runnerName(idx, runner))}
+				return nil
+			})
+		}
+		go func() {
+			waitErr = eg.Wait()
+			close(results)
+		}()
+		for r := range results {
+			if !p.ordered {
+				if err := pipe.SendContext(ctx, r.output); err != nil {
+					return err
+				}
+				continue
+			}
+			pending[r.idx] = r.output
+			for next < len(pending) && pending[next] != nil {
+				if err := pipe.SendContext(ctx, pending[next]); err != nil {
+					return err
+				}
+				next++
+			}
+		}
+		if waitErr != nil {
+			return waitErr
+		}
+		merged, err := p.merger(ctx, outputs)
 		if err != nil {
 			return err
 		}
-		pipe.Send(output)
-		return nil
+		return pipe.SendContext(ctx, merged)
 	})
 	return pipe, nil
 }
+
+// runnerName returns the name of the runner if it has one, or its index otherwise.
+func runnerName(idx int, runner blades.Runnable) string {
+	if named, ok := runner.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return strconv.Itoa(idx)
+}
+
+// tagRunner returns a shallow copy of the message with the runner name recorded in its metadata.
+func tagRunner(m *blades.Message, name string) *blades.Message {
+	tagged := *m
+	tagged.Metadata = maps.Clone(m.Metadata)
+	if tagged.Metadata == nil {
+		tagged.Metadata = make(map[string]string, 1)
+	}
+	tagged.Metadata[ParallelRunnerKey] = name
+	return &tagged
+}
@@ -0,0 +1,217 @@
+package flow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/blades"
+)
+
+// gatedRunner replies with its text once release is closed.
+type gatedRunner struct {
+	text    string
+	release chan struct{}
+}
+
+func newGatedRunner(text string) *gatedRunner {
+	return &gatedRunner{text: text, release: make(chan struct{})}
+}
+
+func (r *gatedRunner) Run(ctx context.Context, p *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
+	select {
+	case <-r.release:
+		return blades.AssistantMessage(r.text), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (r *gatedRunner) RunStream(ctx context.Context, p *blades.Prompt, opts ...blades.ModelOption) (blades.Streamable[*blades.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+// releasingRunner replies with text and then releases gate, so gate completes after it.
+func releasingRunner(text string, gate *gatedRunner) blades.Runnable {
+	return &blades.HandleFunc{
+		Handle: func(ctx context.Context, p *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
+			defer close(gate.release)
+			return blades.AssistantMessage(text), nil
+		},
+	}
+}
+
+func TestParallelMergerOrder(t *testing.T) {
+	var got []string
+	merger := func(ctx context.Context, outputs []*blades.Message) (*blades.Message, error) {
+		for _, output := range outputs {
+			got = append(got, output.Text())
+		}
+		return blades.AssistantMessage("merged"), nil
+	}
+	// The first runner finishes last, so completion order differs from index order.
+	first := newGatedRunner("first")
+	parallel := NewParallel([]blades.Runnable{
+		first,
+		releasingRunner("second", first),
+	}, WithParallelMerger(merger))
+	if _, err := parallel.Run(context.Background(), blades.NewPrompt(blades.UserMessage("go"))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Fatalf("merger outputs = %q; want [first second]", got)
+	}
+}
+
+func TestParallelBestEffort(t *testing.T) {
+	boom := errors.New("boom")
+	failing := &blades.HandleFunc{
+		Handle: func(ctx context.Context, p *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
+			return nil, boom
+		},
+	}
+	var got []ParallelResult
+	merger := func(ctx context.Context, results []ParallelResult) (*blades.Message, error) {
+		got = results
+		var texts []string
+		for _, result := range results {
+			if result.Err == nil {
+				texts = append(texts, result.Output.Text())
+			}
+		}
+		if len(texts) == 0 {
+			return nil, errors.New("all runners failed")
+		}
+		return blades.AssistantMessage(strings.Join(texts, ",")), nil
+	}
+	slow := newGatedRunner("slow")
+	parallel := NewParallelBestEffort([]blades.Runnable{
+		slow,
+		failing,
+		releasingRunner("fast", slow),
+	}, merger)
+	output, err := parallel.Run(context.Background(), blades.NewPrompt(blades.UserMessage("go")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.Text() != "slow,fast" {
+		t.Fatalf("Run() = %q; want %q", output.Text(), "slow,fast")
+	}
+	if len(got) != 3 || !errors.Is(got[1].Err, boom) || got[1].Output != nil {
+		t.Fatalf("unexpected results: %+v", got)
+	}
+
+	strict := NewParallel([]blades.Runnable{textRunner("ok"), failing})
+	if _, err := strict.Run(context.Background(), blades.NewPrompt()); !errors.Is(err, boom) {
+		t.Fatalf("expected strict Parallel to fail with boom, got %v", err)
+	}
+}
+
+func TestParallelRunStream(t *testing.T) {
+	// collect releases the gates one at a time, releasing the next once an output arrives.
+	// It returns the tagged runner outputs and the text of the final merged output.
+	collect := func(p *Parallel, gates ...*gatedRunner) ([]string, []string, string) {
+		stream, err := p.RunStream(context.Background(), blades.NewPrompt(blades.UserMessage("go")))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		close(gates[0].release)
+		gates = gates[1:]
+		var (
+			texts, sources []string
+			merged         string
+		)
+		for stream.Next() {
+			output, err := stream.Current()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := output.Metadata[ParallelRunnerKey]; !ok {
+				if len(gates) > 0 || merged != "" {
+					t.Fatalf("untagged output %q before all runners finished", output.Text())
+				}
+				merged = output.Text()
+				continue
+			}
+			if merged != "" {
+				t.Fatalf("runner output %q after the merged output", output.Text())
+			}
+			texts = append(texts, output.Text())
+			sources = append(sources, output.Metadata[ParallelRunnerKey])
+			if len(gates) > 0 {
+				close(gates[0].release)
+				gates = gates[1:]
+			}
+		}
+		if _, err := stream.Current(); err != nil {
+			t.Fatalf("unexpected stream error: %v", err)
+		}
+		return texts, sources, merged
+	}
+
+	slow, fast := newGatedRunner("slow"), newGatedRunner("fast")
+	texts, sources, merged := collect(NewParallel([]blades.Runnable{slow, fast}), fast, slow)
+	if strings.Join(texts, ",") != "fast,slow" || strings.Join(sources, ",") != "1,0" {
+		t.Fatalf("completion order = %q from %q; want [fast slow] from [1 0]", texts, sources)
+	}
+	if merged != "slow\nfast" {
+		t.Fatalf("merged output = %q; want %q", merged, "slow\nfast")
+	}
+
+	// In declaration order nothing is emitted until the first runner completes,
+	// so release both up front, the second runner first.
+	slow, fast = newGatedRunner("slow"), newGatedRunner("fast")
+	close(fast.release)
+	texts, sources, merged = collect(NewParallel([]blades.Runnable{slow, fast}, WithParallelOrdered()), slow)
+	if strings.Join(texts, ",") != "slow,fast" || strings.Join(sources, ",") != "0,1" {
+		t.Fatalf("declaration order = %q from %q; want [slow fast] from [0 1]", texts, sources)
+	}
+	if merged != "slow\nfast" {
+		t.Fatalf("merged output = %q; want %q", merged, "slow\nfast")
+	}
+}
+
+func TestParallelRunStreamError(t *testing.T) {
+	boom := errors.New("boom")
+	failing := &blades.HandleFunc{
+		Handle: func(ctx context.Context, p *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
+			return nil, boom
+		},
+	}
+	blocked := newGatedRunner("never")
+	stream, err := NewParallel([]blades.Runnable{blocked, failing}).RunStream(context.Background(), blades.NewPrompt())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for stream.Next() {
+		t.Fatal("expected no outputs")
+	}
+	if _, err := stream.Current(); !errors.Is(err, boom) {
+		t.Fatalf("stream error = %v; want %v", err, boom)
+	}
+}
+
+func TestParallelRunStreamCancel(t *testing.T) {
+	// More runners than the stream buffers, so sending blocks until the consumer reads.
+	runners := make([]blades.Runnable, 100)
+	for i := range runners {
+		runners[i] = textRunner("ok")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := NewParallel(runners).RunStream(ctx, blades.NewPrompt())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cancel()
+	received := 0
+	for stream.Next() {
+		received++
+	}
+	if received == len(runners) {
+		t.Fatalf("received all %d outputs; want the stream to stop after cancel", received)
+	}
+	if _, err := stream.Current(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("stream error = %v; want %v", err, context.Canceled)
+	}
+}
@@ -6,9 +6,25 @@ import (
 	"github.com/go-kratos/blades"
 )
 
+// SequentialOption defines a function type for configuring Sequential instances.
+type SequentialOption func(*Sequential)
+
+// StepMiddleware wraps the runner of a single step, identified by its zero-based index.
+// It may add logging, metrics, or retries, or short-circuit by not calling next.
+type StepMiddleware func(step int, next blades.Runnable) blades.Runnable
+
+// WithStepMiddleware sets middlewares applied to each step's runner.
+// The first middleware becomes the outermost wrapper.
+func WithStepMiddleware(ms ...StepMiddleware) SequentialOption {
+	return func(c *Sequential) {
+		c.middlewares = ms
+	}
+}
+
 // Sequential represents a sequence of Runnable runners that process input sequentially.
 type Sequential struct {
-	runners []blades.Runnable
+	runners     []blades.Runnable
+	middlewares []StepMiddleware
 }
 
 // NewSequential creates a new Sequential with the given runners.
@@ -18,13 +34,29 @@ func NewSequential(runners ...blades.Runnable) *Sequential {
 	}
 }
 
+// NewSequentialWithOptions creates a new Sequential with the given runners and options.
+func NewSequentialWithOptions(runners []blades.Runnable, opts ...SequentialOption) *Sequential {
+	c := NewSequential(runners...)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
 // Run executes the chain of runners sequentially, passing the output of one as the input to the next.
+// It stops before invoking the next runner once ctx is cancelled, returning the context error.
 func (c *Sequential) Run(ctx context.Context, input *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
 	var (
 		err    error
 		output *blades.Message
 	)
-	for _, runner := range c.runners {
+	for i, runner := range c.runners {
+		if err = ctx.Err(); err != nil {
+			return output, err
+		}
+		for j := len(c.middlewares) - 1; j >= 0; j-- { // apply in reverse to make middlewares[0] outermost
+			runner = c.middlewares[j](i, runner)
+		}
 		if output, err = runner.Run(ctx, input, opts...); err != nil {
 			return output, err
 		}
@@ -41,8 +73,7 @@ func (c *Sequential) RunStream(ctx context.Context, input *blades.Prompt, opts .
 		if err != nil {
 			return err
 		}
-		pipe.Send(output)
-		return nil
+		return pipe.SendContext(ctx, output)
 	})
 	return pipe, nil
 }
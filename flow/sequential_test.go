@@ -0,0 +1,81 @@
+package flow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-kratos/blades"
+)
+
+func TestSequentialStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls []string
+	step := func(name string, cancelAfter bool) blades.Runnable {
+		return &blades.HandleFunc{
+			Handle: func(ctx context.Context, p *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
+				calls = append(calls, name)
+				if cancelAfter {
+					cancel()
+				}
+				return blades.AssistantMessage(name), nil
+			},
+		}
+	}
+	seq := NewSequential(step("first", false), step("second", true), step("third", false))
+	output, err := seq.Run(ctx, blades.NewPrompt(blades.UserMessage("go")))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if output == nil || output.Text() != "second" {
+		t.Fatalf("expected last completed output, got %v", output)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("calls = %v; want [first second]", calls)
+	}
+}
+
+func TestSequentialStepMiddleware(t *testing.T) {
+	var steps []int
+	observe := func(step int, next blades.Runnable) blades.Runnable {
+		return &blades.HandleFunc{
+			Handle: func(ctx context.Context, p *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
+				steps = append(steps, step)
+				return next.Run(ctx, p, opts...)
+			},
+		}
+	}
+	skipSecond := func(step int, next blades.Runnable) blades.Runnable {
+		if step != 1 {
+			return next
+		}
+		return &blades.HandleFunc{
+			Handle: func(ctx context.Context, p *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
+				return blades.AssistantMessage("cached"), nil
+			},
+		}
+	}
+	var inputs []string
+	echo := &blades.HandleFunc{
+		Handle: func(ctx context.Context, p *blades.Prompt, opts ...blades.ModelOption) (*blades.Message, error) {
+			inputs = append(inputs, p.Latest().Text())
+			return blades.AssistantMessage(p.Latest().Text() + "!"), nil
+		},
+	}
+	seq := NewSequentialWithOptions([]blades.Runnable{echo, echo, echo}, WithStepMiddleware(observe, skipSecond))
+	output, err := seq.Run(context.Background(), blades.NewPrompt(blades.UserMessage("hi")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.Text() != "cached!" {
+		t.Fatalf("Run() = %q; want %q", output.Text(), "cached!")
+	}
+	if fmt.Sprint(steps) != "[0 1 2]" {
+		t.Fatalf("observed steps = %v; want [0 1 2]", steps)
+	}
+	if fmt.Sprint(inputs) != "[hi cached]" {
+		t.Fatalf("runner inputs = %v; want [hi cached]", inputs)
+	}
+}
@@ -7,4 +7,5 @@ require (
 	github.com/google/jsonschema-go v0.2.3
 	github.com/google/uuid v1.6.0
 	golang.org/x/sync v0.16.0
+	golang.org/x/time v0.12.0
 )
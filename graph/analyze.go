@@ -0,0 +1,79 @@
+package graph
+
+import "fmt"
+
+// Diagnostic describes a structural problem found by Analyze.
+type Diagnostic struct {
+	Node    string
+	Message string
+}
+
+// String returns a human-readable representation of the diagnostic.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s", d.Node, d.Message)
+}
+
+// Analyze statically checks every node reachable from the entry point and reports
+// branches that end without reaching the finish point and cycles with no exit path.
+// Nodes that cannot reach the finish point are reported as lying on a cycle with no
+// exit, leading into such a cycle, or leading only to dead ends.
+// It does not execute handlers or evaluate edge conditions, so a reported path may
+// never be taken at runtime, but any path taken that matches a diagnostic will fail.
+func (g *Graph) Analyze() []Diagnostic {
+	if g.entryPoint == "" || g.finishPoint == "" {
+		return nil
+	}
+	reverse := make(map[string][]string, len(g.edges))
+	for from, edges := range g.edges {
+		for _, edge := range edges {
+			reverse[edge.to] = append(reverse[edge.to], from)
+		}
+	}
+	canFinish := map[string]bool{}
+	queue := []string{g.finishPoint}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if canFinish[node] {
+			continue
+		}
+		canFinish[node] = true
+		queue = append(queue, reverse[node]...)
+	}
+	var diagnostics []Diagnostic
+	for _, node := range g.walk(g.entryPoint) {
+		switch {
+		case node == g.finishPoint:
+		case len(g.edges[node]) == 0:
+			diagnostics = append(diagnostics, Diagnostic{Node: node, Message: "no outgoing edges and not the finish point"})
+		case canFinish[node]:
+		case g.onCycle(node):
+			diagnostics = append(diagnostics, Diagnostic{Node: node, Message: "finish point not reachable; cycle has no exit"})
+		case g.reachesCycle(node):
+			diagnostics = append(diagnostics, Diagnostic{Node: node, Message: "finish point not reachable; leads into a cycle with no exit"})
+		default:
+			diagnostics = append(diagnostics, Diagnostic{Node: node, Message: "finish point not reachable; every path ends at a dead end"})
+		}
+	}
+	return diagnostics
+}
+
+// onCycle reports whether node can reach itself by following edges.
+func (g *Graph) onCycle(node string) bool {
+	for _, edge := range g.edges[node] {
+		if g.reachableFrom(edge.to)[node] {
+			return true
+		}
+	}
+	return false
+}
+
+// reachesCycle reports whether any node reachable from node lies on a cycle.
+func (g *Graph) reachesCycle(node string) bool {
+	for _, next := range g.walk(node) {
+		if g.onCycle(next) {
+			return true
+		}
+	}
+	return false
+}
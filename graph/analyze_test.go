@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGraphAnalyze(t *testing.T) {
+	always := WithEdgeCondition(func(ctx context.Context, state State) bool { return true })
+
+	t.Run("valid", func(t *testing.T) {
+		g := NewGraph()
+		_ = g.AddNode("A", stepHandler("A"))
+		_ = g.AddNode("B", stepHandler("B"))
+		_ = g.AddEdge("A", "A", always)
+		_ = g.AddEdge("A", "B", always)
+		_ = g.SetEntryPoint("A")
+		_ = g.SetFinishPoint("B")
+		if got := g.Analyze(); len(got) != 0 {
+			t.Fatalf("unexpected diagnostics: %v", got)
+		}
+	})
+
+	t.Run("branch missing target", func(t *testing.T) {
+		g := NewGraph()
+		_ = g.AddNode("router", stepHandler("router"))
+		_ = g.AddNode("ok", stepHandler("ok"))
+		_ = g.AddNode("fallback", stepHandler("fallback"))
+		_ = g.AddEdge("router", "ok", always)
+		_ = g.AddEdge("router", "fallback", always)
+		_ = g.SetEntryPoint("router")
+		_ = g.SetFinishPoint("ok")
+		want := []Diagnostic{{Node: "fallback", Message: "no outgoing edges and not the finish point"}}
+		if got := g.Analyze(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Analyze() = %v; want %v", got, want)
+		}
+	})
+
+	t.Run("loop without exit", func(t *testing.T) {
+		g := NewGraph()
+		_ = g.AddNode("start", stepHandler("start"))
+		_ = g.AddNode("draft", stepHandler("draft"))
+		_ = g.AddNode("review", stepHandler("review"))
+		_ = g.AddNode("end", stepHandler("end"))
+		_ = g.AddEdge("start", "end", always)
+		_ = g.AddEdge("start", "draft", always)
+		_ = g.AddEdge("draft", "review")
+		_ = g.AddEdge("review", "draft")
+		_ = g.SetEntryPoint("start")
+		_ = g.SetFinishPoint("end")
+		got := g.Analyze()
+		if len(got) != 2 || got[0].Node != "draft" || got[1].Node != "review" {
+			t.Fatalf("Analyze() = %v; want diagnostics for draft and review", got)
+		}
+	})
+
+	t.Run("acyclic dead end", func(t *testing.T) {
+		g := NewGraph()
+		_ = g.AddNode("start", stepHandler("start"))
+		_ = g.AddNode("detour", stepHandler("detour"))
+		_ = g.AddNode("stuck", stepHandler("stuck"))
+		_ = g.AddNode("end", stepHandler("end"))
+		_ = g.AddEdge("start", "end", always)
+		_ = g.AddEdge("start", "detour", always)
+		_ = g.AddEdge("detour", "stuck")
+		_ = g.SetEntryPoint("start")
+		_ = g.SetFinishPoint("end")
+		want := []Diagnostic{
+			{Node: "detour", Message: "finish point not reachable; every path ends at a dead end"},
+			{Node: "stuck", Message: "no outgoing edges and not the finish point"},
+		}
+		if got := g.Analyze(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Analyze() = %v; want %v", got, want)
+		}
+	})
+
+	t.Run("path into cycle without exit", func(t *testing.T) {
+		g := NewGraph()
+		_ = g.AddNode("start", stepHandler("start"))
+		_ = g.AddNode("enter", stepHandler("enter"))
+		_ = g.AddNode("spin", stepHandler("spin"))
+		_ = g.AddNode("end", stepHandler("end"))
+		_ = g.AddEdge("start", "end", always)
+		_ = g.AddEdge("start", "enter", always)
+		_ = g.AddEdge("enter", "spin")
+		_ = g.AddEdge("spin", "spin")
+		_ = g.SetEntryPoint("start")
+		_ = g.SetFinishPoint("end")
+		want := []Diagnostic{
+			{Node: "enter", Message: "finish point not reachable; leads into a cycle with no exit"},
+			{Node: "spin", Message: "finish point not reachable; cycle has no exit"},
+		}
+		if got := g.Analyze(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Analyze() = %v; want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Checkpointer persists the progress of a graph run so that it can resume after a failure.
+type Checkpointer interface {
+	// Save records that node completed with the given state in the run.
+	Save(ctx context.Context, runID, node string, state State) error
+	// Load returns the last completed node and its state for the run.
+	// It returns an empty node name if the run has no checkpoint.
+	Load(ctx context.Context, runID string) (node string, state State, err error)
+	// Delete removes the checkpoint of the run. Deleting a missing checkpoint is not an error.
+	Delete(ctx context.Context, runID string) error
+}
+
+// WithCheckpointer sets the checkpointer used to save progress after each node.
+// Checkpointing is only active for executions whose context carries a run ID (see NewRunContext).
+// A checkpoint holds a single completed node, so Compile rejects checkpointed graphs in which
+// a node can fan out to several successors.
+func WithCheckpointer(checkpointer Checkpointer) Option {
+	return func(g *Graph) {
+		g.checkpointer = checkpointer
+	}
+}
+
+type ctxRunKey struct{}
+
+// NewRunContext returns a new context carrying the run ID used for checkpointing.
+func NewRunContext(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, ctxRunKey{}, runID)
+}
+
+// RunIDFromContext retrieves the run ID from the context, if present.
+func RunIDFromContext(ctx context.Context) (string, bool) {
+	runID, ok := ctx.Value(ctxRunKey{}).(string)
+	return runID, ok && runID != ""
+}
+
+// checkFanOut returns an error if a node reachable from the entry point can fan out.
+// A fan-out leaves pending branches and join counts that a checkpoint cannot capture.
+func (g *Graph) checkFanOut() error {
+	for _, node := range g.walk(g.entryPoint) {
+		edges := g.edges[node]
+		if len(edges) < 2 {
+			continue
+		}
+		conditional, switched := 0, 0
+		for _, edge := range edges {
+			if edge.conditional() {
+				conditional++
+			}
+			if edge.selector != nil && edge.selector == edges[0].selector {
+				switched++
+			}
+		}
+		// Mixed edges follow the first match only, as do the disjoint cases of a single
+		// switch; other uniform edges may fan out.
+		if switched == len(edges) {
+			continue
+		}
+		if conditional == 0 || conditional == len(edges) {
+			return fmt.Errorf("graph: checkpointing does not support fan-out from node %s", node)
+		}
+	}
+	return nil
+}
+
+type checkpoint struct {
+	node  string
+	state State
+}
+
+// InMemoryCheckpointer is an in-memory implementation of Checkpointer.
+type InMemoryCheckpointer struct {
+	mu          sync.RWMutex
+	checkpoints map[string]checkpoint
+}
+
+// NewInMemoryCheckpointer creates a new InMemoryCheckpointer.
+func NewInMemoryCheckpointer() *InMemoryCheckpointer {
+	return &InMemoryCheckpointer{checkpoints: make(map[string]checkpoint)}
+}
+
+// Save records the last completed node and its state for the run.
+func (c *InMemoryCheckpointer) Save(ctx context.Context, runID, node string, state State) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checkpoints[runID] = checkpoint{node: node, state: state.Clone()}
+	return nil
+}
+
+// Load returns the last completed node and its state for the run.
+func (c *InMemoryCheckpointer) Load(ctx context.Context, runID string) (string, State, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cp, ok := c.checkpoints[runID]
+	if !ok {
+		return "", nil, nil
+	}
+	return cp.node, cp.state.Clone(), nil
+}
+
+// Delete removes the checkpoint of the run.
+func (c *InMemoryCheckpointer) Delete(ctx context.Context, runID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.checkpoints, runID)
+	return nil
+}
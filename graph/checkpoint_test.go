@@ -0,0 +1,166 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGraphCheckpointResume(t *testing.T) {
+	var (
+		calls = map[string]int{}
+		fail  = true
+		boom  = errors.New("boom")
+	)
+	counted := func(name string) Handler {
+		return func(ctx context.Context, state State) (State, error) {
+			calls[name]++
+			if name == "C" && fail {
+				return nil, boom
+			}
+			return appendStep(state, name), nil
+		}
+	}
+	checkpointer := NewInMemoryCheckpointer()
+	g := NewGraph(WithCheckpointer(checkpointer))
+	for _, name := range []string{"A", "B", "C", "D"} {
+		_ = g.AddNode(name, counted(name))
+	}
+	_ = g.AddEdge("A", "B")
+	_ = g.AddEdge("B", "C")
+	_ = g.AddEdge("C", "D")
+	_ = g.SetEntryPoint("A")
+	_ = g.SetFinishPoint("D")
+
+	ctx := NewRunContext(context.Background(), "run-1")
+	executor, err := g.Compile()
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	if _, err := executor.Execute(ctx, State{}); !errors.Is(err, boom) {
+		t.Fatalf("expected boom error, got %v", err)
+	}
+	if node, _, _ := checkpointer.Load(ctx, "run-1"); node != "B" {
+		t.Fatalf("checkpoint node = %q; want %q", node, "B")
+	}
+
+	fail = false
+	executor, err = g.Compile()
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	result, err := executor.Execute(ctx, State{})
+	if err != nil {
+		t.Fatalf("resume error: %v", err)
+	}
+	if steps := getStringSlice(result[stepsKey]); !reflect.DeepEqual(steps, []string{"A", "B", "C", "D"}) {
+		t.Fatalf("steps = %v", steps)
+	}
+	want := map[string]int{"A": 1, "B": 1, "C": 2, "D": 1}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls = %v; want %v", calls, want)
+	}
+
+	// A completed run deletes its checkpoint, so the same run ID starts over.
+	if node, _, _ := checkpointer.Load(ctx, "run-1"); node != "" {
+		t.Fatalf("checkpoint node after completion = %q; want none", node)
+	}
+	executor, _ = g.Compile()
+	result, err = executor.Execute(ctx, State{})
+	if err != nil {
+		t.Fatalf("rerun error: %v", err)
+	}
+	if steps := getStringSlice(result[stepsKey]); !reflect.DeepEqual(steps, []string{"A", "B", "C", "D"}) {
+		t.Fatalf("rerun steps = %v", steps)
+	}
+	want = map[string]int{"A": 2, "B": 2, "C": 3, "D": 2}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls = %v; want %v", calls, want)
+	}
+	if len(checkpointer.checkpoints) != 0 {
+		t.Fatalf("expected no checkpoints after completion, got %v", checkpointer.checkpoints)
+	}
+}
+
+func TestGraphCheckpointRejectsFanOut(t *testing.T) {
+	always := func(ctx context.Context, state State) bool { return true }
+	tests := []struct {
+		name    string
+		build   func(g *Graph)
+		wantErr bool
+	}{
+		{
+			name: "unconditional fan-out",
+			build: func(g *Graph) {
+				_ = g.AddEdge("A", "B")
+				_ = g.AddEdge("A", "C")
+			},
+			wantErr: true,
+		},
+		{
+			name: "conditional fan-out",
+			build: func(g *Graph) {
+				_ = g.AddEdge("A", "B", WithEdgeCondition(always))
+				_ = g.AddEdge("A", "C", WithEdgeCondition(always))
+			},
+			wantErr: true,
+		},
+		{
+			name: "mixed edges",
+			build: func(g *Graph) {
+				_ = g.AddEdge("A", "B", WithEdgeCondition(always))
+				_ = g.AddEdge("A", "C")
+			},
+		},
+		{
+			name: "switch",
+			build: func(g *Graph) {
+				_ = g.AddSwitch("A", func(ctx context.Context, state State) string { return "b" },
+					map[string]string{"b": "B", "c": "C"}, "")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGraph(WithCheckpointer(NewInMemoryCheckpointer()))
+			for _, name := range []string{"A", "B", "C", "D"} {
+				_ = g.AddNode(name, stepHandler(name))
+			}
+			tt.build(g)
+			_ = g.AddEdge("B", "D")
+			_ = g.AddEdge("C", "D")
+			_ = g.SetEntryPoint("A")
+			_ = g.SetFinishPoint("D")
+			_, err := g.Compile()
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "fan-out from node A") {
+					t.Fatalf("compile error = %v; want fan-out error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("compile error: %v", err)
+			}
+		})
+	}
+}
+
+func TestGraphCheckpointRequiresRunID(t *testing.T) {
+	checkpointer := NewInMemoryCheckpointer()
+	g := NewGraph(WithCheckpointer(checkpointer))
+	_ = g.AddNode("A", stepHandler("A"))
+	_ = g.SetEntryPoint("A")
+	_ = g.SetFinishPoint("A")
+	executor, err := g.Compile()
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	if _, err := executor.Execute(context.Background(), State{}); err != nil {
+		t.Fatalf("run error: %v", err)
+	}
+	if len(checkpointer.checkpoints) != 0 {
+		t.Fatalf("expected no checkpoints without a run ID, got %v", checkpointer.checkpoints)
+	}
+}
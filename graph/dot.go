@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DOT renders the graph in Graphviz DOT format. The entry point is drawn as an
+// Mdiamond and the finish point as an Msquare; conditional edges are dashed and
+// labeled with their edge label, if any. It can be called before or after Compile.
+func (g *Graph) DOT() string {
+	names := make([]string, 0, len(g.nodes))
+	for name := range g.nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString("digraph {\n")
+	for _, name := range names {
+		var attrs []string
+		switch name {
+		case g.entryPoint:
+			attrs = append(attrs, "shape=Mdiamond")
+			if name == g.finishPoint {
+				attrs = append(attrs, "peripheries=2")
+			}
+		case g.finishPoint:
+			attrs = append(attrs, "shape=Msquare")
+		}
+		writeDOTStatement(&b, fmt.Sprintf("%q", name), attrs)
+	}
+	sources := make([]string, 0, len(g.edges))
+	for from := range g.edges {
+		sources = append(sources, from)
+	}
+	sort.Strings(sources)
+	for _, from := range sources {
+		for _, edge := range g.edges[from] {
+			var attrs []string
+			if edge.conditional() {
+				attrs = append(attrs, "style=dashed")
+			}
+			if edge.label != "" {
+				attrs = append(attrs, fmt.Sprintf("label=%q", edge.label))
+			}
+			writeDOTStatement(&b, fmt.Sprintf("%q -> %q", from, edge.to), attrs)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func writeDOTStatement(b *strings.Builder, stmt string, attrs []string) {
+	b.WriteString("\t")
+	b.WriteString(stmt)
+	if len(attrs) > 0 {
+		b.WriteString(" [" + strings.Join(attrs, ", ") + "]")
+	}
+	b.WriteString(";\n")
+}
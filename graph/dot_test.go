@@ -0,0 +1,35 @@
+package graph
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGraphDOT(t *testing.T) {
+	g := NewGraph()
+	_ = g.AddNode("start", stepHandler("start"))
+	_ = g.AddNode("review", stepHandler("review"))
+	_ = g.AddNode("end", stepHandler("end"))
+	_ = g.AddEdge("start", "review")
+	_ = g.AddEdge("review", "start", WithEdgeCondition(func(ctx context.Context, state State) bool {
+		return false
+	}), WithEdgeLabel("revise"))
+	_ = g.AddEdge("review", "end", WithEdgeCondition(func(ctx context.Context, state State) bool {
+		return true
+	}))
+	_ = g.SetEntryPoint("start")
+	_ = g.SetFinishPoint("end")
+
+	want := `digraph {
+	"end" [shape=Msquare];
+	"review";
+	"start" [shape=Mdiamond];
+	"review" -> "start" [style=dashed, label="revise"];
+	"review" -> "end" [style=dashed];
+	"start" -> "review";
+}
+`
+	if got := g.DOT(); got != want {
+		t.Fatalf("DOT() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+package graph
+
+// EventType identifies the kind of an execution Event.
+type EventType string
+
+const (
+	// EventNodeStart is emitted before a node handler runs.
+	EventNodeStart EventType = "node_start"
+	// EventNodeEnd is emitted after a node handler returns.
+	EventNodeEnd EventType = "node_end"
+	// EventEdge is emitted when an edge is taken from one node to another.
+	EventEdge EventType = "edge"
+	// EventGraphEnd is emitted once when execution completes, successfully or not.
+	EventGraphEnd EventType = "graph_end"
+)
+
+// Event describes a step in the lifecycle of a graph execution.
+type Event struct {
+	Type EventType
+	// Node is the node the event refers to; for edge events it is the source node.
+	Node string
+	// To is the target node of an edge event.
+	To string
+	// State is the input state for node start events, the output state for node end
+	// events, and the final state for the graph end event.
+	State State
+	// Err is the error returned by the node or by the execution, if any.
+	Err error
+}
@@ -3,6 +3,8 @@ package graph
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 )
@@ -16,6 +18,8 @@ type Executor struct {
 	finished    bool
 	finishState State
 	stepCount   int // tracks total number of steps executed
+	observer    func(Event)
+	history     []StateSnapshot
 }
 
 // Step represents a single execution step in the graph.
@@ -46,8 +50,69 @@ func NewExecutor(g *Graph) *Executor {
 	}
 }
 
+// Plan returns the planned execution order of the compiled graph: the nodes reachable
+// from the entry point in breadth-first order, following edges in the order they were added.
+// Conditional edges are included regardless of whether they will be taken at runtime.
+func (e *Executor) Plan() []string {
+	return e.graph.walk(e.graph.entryPoint)
+}
+
+// History returns the state snapshots recorded after each node execution, in the
+// order the nodes completed. It is empty unless the graph uses WithStateHistory.
+// Parallel branches are recorded in edge order once the whole fan-out has completed.
+func (e *Executor) History() []StateSnapshot {
+	return slices.Clone(e.history)
+}
+
+// ExecuteStream runs the graph execution in a new goroutine and returns a channel of
+// lifecycle events. The final event is always EventGraphEnd, carrying the final state
+// or the execution error, after which the channel is closed. Callers should drain the
+// channel or cancel ctx to stop a blocked execution. Once ctx is cancelled, unread
+// events may be discarded to make room, but EventGraphEnd is still delivered.
+func (e *Executor) ExecuteStream(ctx context.Context, state State) <-chan Event {
+	events := make(chan Event, 16)
+	send := func(ev Event) {
+		select {
+		case events <- ev:
+		case <-ctx.Done():
+		}
+	}
+	go func() {
+		defer close(events)
+		e.observer = send
+		final, err := e.Execute(ctx, state)
+		end := Event{Type: EventGraphEnd, State: final, Err: err}
+		select {
+		case events <- end:
+			return
+		case <-ctx.Done():
+		}
+		// The run is cancelled and the consumer may have stopped reading: drop unread
+		// events until the terminal event fits. All other senders have returned.
+		for {
+			select {
+			case events <- end:
+				return
+			default:
+			}
+			select {
+			case <-events:
+			default:
+			}
+		}
+	}()
+	return events
+}
+
 // Execute runs the graph execution starting from the given state.
+// If the graph has a checkpointer and ctx carries a run ID with a saved checkpoint,
+// execution resumes after the last completed node instead of starting from the entry point.
+// The checkpoint is deleted once the run finishes, so a later execution with the same run ID
+// starts over.
 func (e *Executor) Execute(ctx context.Context, state State) (State, error) {
+	if err := e.resume(ctx); err != nil {
+		return nil, err
+	}
 	for len(e.queue) > 0 {
 		// Check if we've exceeded the maximum number of steps
 		if e.stepCount >= e.graph.maxSteps {
@@ -65,6 +130,10 @@ func (e *Executor) Execute(ctx context.Context, state State) (State, error) {
 		if err != nil {
 			return nil, err
 		}
+		e.record(step.node, nextState)
+		if err := e.saveCheckpoint(ctx, step.node, nextState); err != nil {
+			return nil, err
+		}
 		if e.handleFinish(step.node, nextState) {
 			continue
 		}
@@ -73,11 +142,108 @@ func (e *Executor) Execute(ctx context.Context, state State) (State, error) {
 		}
 	}
 	if e.finished {
+		if err := e.deleteCheckpoint(ctx); err != nil {
+			return nil, err
+		}
 		return e.finishState, nil
 	}
 	return nil, fmt.Errorf("graph: finish node not reachable: %s", e.graph.finishPoint)
 }
 
+// resume restores the executor from the checkpoint of the run in ctx, if any.
+func (e *Executor) resume(ctx context.Context) error {
+	runID, ok := RunIDFromContext(ctx)
+	if e.graph.checkpointer == nil || !ok {
+		return nil
+	}
+	node, state, err := e.graph.checkpointer.Load(ctx, runID)
+	if err != nil {
+		return fmt.Errorf("graph: load checkpoint: %w", err)
+	}
+	if node == "" {
+		return nil
+	}
+	if _, ok := e.graph.nodes[node]; !ok {
+		return fmt.Errorf("graph: checkpoint references unknown node: %s", node)
+	}
+	e.queue = nil
+	e.visited[node] = true
+	if e.handleFinish(node, state) {
+		return nil
+	}
+	return e.processOutgoingEdges(ctx, Step{node: node}, state)
+}
+
+// saveCheckpoint records the completed node for the run in ctx, if checkpointing is active.
+func (e *Executor) saveCheckpoint(ctx context.Context, node string, state State) error {
+	runID, ok := RunIDFromContext(ctx)
+	if e.graph.checkpointer == nil || !ok {
+		return nil
+	}
+	if err := e.graph.checkpointer.Save(ctx, runID, node, state); err != nil {
+		return fmt.Errorf("graph: save checkpoint for node %s: %w", node, err)
+	}
+	return nil
+}
+
+// deleteCheckpoint removes the checkpoint of the finished run in ctx, if checkpointing is active.
+func (e *Executor) deleteCheckpoint(ctx context.Context) error {
+	runID, ok := RunIDFromContext(ctx)
+	if e.graph.checkpointer == nil || !ok {
+		return nil
+	}
+	if err := e.graph.checkpointer.Delete(ctx, runID); err != nil {
+		return fmt.Errorf("graph: delete checkpoint: %w", err)
+	}
+	return nil
+}
+
+// record appends a snapshot of the state produced by node, if state history is enabled.
+func (e *Executor) record(node string, state State) {
+	if !e.graph.stateHistory {
+		return
+	}
+	e.history = append(e.history, StateSnapshot{
+		Node:  node,
+		State: state.deepClone(),
+		Step:  len(e.history) + 1,
+	})
+}
+
+// notify delivers an event to the observer, if one is set.
+func (e *Executor) notify(ev Event) {
+	if e.observer != nil {
+		e.observer(ev)
+	}
+}
+
+// invoke runs a node handler, reporting its lifecycle to the graph hooks and the event observer.
+func (e *Executor) invoke(ctx context.Context, node string, handler Handler, state State) (State, error) {
+	hooks := e.graph.hooks
+	e.notify(Event{Type: EventNodeStart, Node: node, State: state})
+	if hooks.OnNodeStart != nil {
+		hooks.OnNodeStart(ctx, node, state)
+	}
+	var start time.Time
+	if hooks.OnNodeEnd != nil {
+		start = time.Now()
+	}
+	nextState, err := handler(ctx, state)
+	if hooks.OnNodeEnd != nil {
+		hooks.OnNodeEnd(ctx, node, nextState, err, time.Since(start))
+	}
+	e.notify(Event{Type: EventNodeEnd, Node: node, State: nextState, Err: err})
+	return nextState, err
+}
+
+// takeEdge reports an edge taken from one node to another.
+func (e *Executor) takeEdge(ctx context.Context, from, to string) {
+	e.notify(Event{Type: EventEdge, Node: from, To: to})
+	if e.graph.hooks.OnEdge != nil {
+		e.graph.hooks.OnEdge(ctx, from, to)
+	}
+}
+
 func (e *Executor) dequeue() Step {
 	step := e.queue[0]
 	e.queue = e.queue[1:]
@@ -106,7 +272,7 @@ func (e *Executor) executeNode(ctx context.Context, step Step) (State, error) {
 	if len(e.graph.middlewares) > 0 {
 		handler = ChainMiddlewares(e.graph.middlewares...)(handler)
 	}
-	nextState, err := handler(ctx, state)
+	nextState, err := e.invoke(ctx, step.node, handler, state)
 	if err != nil {
 		return nil, fmt.Errorf("graph: node %s: %w", step.node, err)
 	}
@@ -135,6 +301,12 @@ func (e *Executor) processOutgoingEdges(ctx context.Context, step Step, state St
 	if err != nil {
 		return err
 	}
+	for _, next := range resolution.immediate {
+		e.takeEdge(ctx, step.node, next.node)
+	}
+	for _, edge := range resolution.fanOut {
+		e.takeEdge(ctx, step.node, edge.to)
+	}
 	// Handle immediate transitions (single matched conditional edge)
 	if len(resolution.immediate) > 0 {
 		e.enqueueSteps(resolution.immediate, resolution.prepend)
@@ -189,18 +361,45 @@ func (e *Executor) resolveEdges(ctx context.Context, step Step, state State) (ed
 	if len(conditionalEdges) == 0 {
 		return edgeResolution{fanOut: edges}, nil
 	}
+	match := newEdgeMatcher()
 	// Case 2: All edges are conditional - evaluate and fan out to matches
 	if len(unconditionalEdges) == 0 {
-		return e.resolveAllConditional(ctx, state, conditionalEdges, step.node)
+		return e.resolveAllConditional(ctx, state, conditionalEdges, step.node, match)
 	}
 	// Case 3: Mixed edges - evaluate in order, first match wins (conditional or unconditional)
-	return e.resolveMixed(ctx, state, edges, step.node)
+	return e.resolveMixed(ctx, state, edges, step.node, match)
+}
+
+// edgeMatcher evaluates edge conditions for a single step, running each switch
+// selector at most once and reusing its result for the remaining case edges.
+type edgeMatcher struct {
+	selected map[*switchSelector]string
+}
+
+func newEdgeMatcher() *edgeMatcher {
+	return &edgeMatcher{selected: make(map[*switchSelector]string)}
+}
+
+// matches reports whether the edge should be followed for the given state.
+func (m *edgeMatcher) matches(ctx context.Context, state State, edge conditionalEdge) bool {
+	switch {
+	case edge.selector != nil:
+		key, ok := m.selected[edge.selector]
+		if !ok {
+			key = edge.selector.selector(ctx, state)
+			m.selected[edge.selector] = key
+		}
+		return slices.Contains(edge.cases, key)
+	case edge.condition != nil:
+		return edge.condition(ctx, state)
+	}
+	return true
 }
 
 // classifyEdges separates edges into conditional and unconditional
 func (e *Executor) classifyEdges(edges []conditionalEdge) (conditional, unconditional []conditionalEdge) {
 	for _, edge := range edges {
-		if edge.condition != nil {
+		if edge.conditional() {
 			conditional = append(conditional, edge)
 		} else {
 			unconditional = append(unconditional, edge)
@@ -210,10 +409,10 @@ func (e *Executor) classifyEdges(edges []conditionalEdge) (conditional, uncondit
 }
 
 // resolveAllConditional handles the case where all edges are conditional
-func (e *Executor) resolveAllConditional(ctx context.Context, state State, edges []conditionalEdge, nodeName string) (edgeResolution, error) {
+func (e *Executor) resolveAllConditional(ctx context.Context, state State, edges []conditionalEdge, nodeName string, match *edgeMatcher) (edgeResolution, error) {
 	matched := make([]conditionalEdge, 0, len(edges))
 	for _, edge := range edges {
-		if edge.condition(ctx, state) {
+		if match.matches(ctx, state, edge) {
 			matched = append(matched, edge)
 		}
 	}
@@ -236,9 +435,9 @@ func (e *Executor) resolveAllConditional(ctx context.Context, state State, edges
 
 // resolveMixed handles the case where edges are a mix of conditional and unconditional
 // First match wins (conditional edges are checked first, then unconditional)
-func (e *Executor) resolveMixed(ctx context.Context, state State, edges []conditionalEdge, nodeName string) (edgeResolution, error) {
+func (e *Executor) resolveMixed(ctx context.Context, state State, edges []conditionalEdge, nodeName string, match *edgeMatcher) (edgeResolution, error) {
 	for _, edge := range edges {
-		if edge.condition == nil || edge.condition(ctx, state) {
+		if match.matches(ctx, state, edge) {
 			return edgeResolution{
 				immediate: []Step{{
 					node:         edge.to,
@@ -281,7 +480,7 @@ func (e *Executor) fanOutParallel(ctx context.Context, step Step, state State, e
 			if handler == nil {
 				return fmt.Errorf("graph: node %s handler missing", edge.to)
 			}
-			nextState, err := handler(egCtx, state.Clone())
+			nextState, err := e.invoke(egCtx, edge.to, handler, state.Clone())
 			if err != nil {
 				return fmt.Errorf("graph: node %s: %w", edge.to, err)
 			}
@@ -297,6 +496,7 @@ func (e *Executor) fanOutParallel(ctx context.Context, step Step, state State, e
 	mergedBranches := state.Clone()
 	for _, result := range results {
 		edge := edges[result.idx]
+		e.record(edge.to, result.state)
 		e.waiting[edge.to]--
 		branchEdges := e.graph.edges[edge.to]
 		for _, nextEdge := range branchEdges {
@@ -2,7 +2,11 @@ package graph
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 )
 
 // Option configures the Graph behavior.
@@ -30,6 +34,58 @@ func WithMaxSteps(maxSteps int) Option {
 	}
 }
 
+// WithUnreachableHandler sets a handler invoked by Compile with the sorted names of
+// nodes that cannot be reached from the entry point. Returning an error fails
+// compilation; returning nil treats the report as a warning.
+func WithUnreachableHandler(handler func(nodes []string) error) Option {
+	return func(g *Graph) {
+		g.unreachableHandler = handler
+	}
+}
+
+// Hooks are optional callbacks invoked around each node execution, e.g. to record
+// metrics or tracing spans. Unset callbacks are skipped.
+type Hooks struct {
+	// OnNodeStart is called before a node handler runs, with its input state.
+	OnNodeStart func(ctx context.Context, node string, state State)
+	// OnNodeEnd is called after a node handler returns, with its output state, error, and duration.
+	OnNodeEnd func(ctx context.Context, node string, state State, err error, elapsed time.Duration)
+	// OnEdge is called for each edge taken after a node completes, including matched conditional edges.
+	OnEdge func(ctx context.Context, from, to string)
+}
+
+// WithHooks sets the hooks invoked around each node execution.
+func WithHooks(hooks Hooks) Option {
+	return func(g *Graph) {
+		g.hooks = hooks
+	}
+}
+
+// WithStateHistory makes the executor record a deep copy of the state after each
+// node runs. The snapshots are available from Executor.History.
+func WithStateHistory() Option {
+	return func(g *Graph) {
+		g.stateHistory = true
+	}
+}
+
+// NodeOption configures a node before it is added to the graph.
+type NodeOption func(*nodeOptions)
+
+// nodeOptions holds per-node configuration.
+type nodeOptions struct {
+	timeout time.Duration
+}
+
+// WithNodeTimeout bounds each invocation of the node's handler by the given duration.
+// The timeout applies independently per invocation; nodes without a timeout run with
+// the parent context unchanged.
+func WithNodeTimeout(d time.Duration) NodeOption {
+	return func(o *nodeOptions) {
+		o.timeout = d
+	}
+}
+
 // EdgeCondition is a function that determines if an edge should be followed based on the current state.
 type EdgeCondition func(ctx context.Context, state State) bool
 
@@ -43,10 +99,40 @@ func WithEdgeCondition(condition EdgeCondition) EdgeOption {
 	}
 }
 
+// withSwitchCases makes the edge a case of the given switch.
+func withSwitchCases(sw *switchSelector, cases []string) EdgeOption {
+	return func(edge *conditionalEdge) {
+		edge.selector = sw
+		edge.cases = cases
+	}
+}
+
+// WithEdgeLabel sets a descriptive label for the edge, used when rendering the graph.
+func WithEdgeLabel(label string) EdgeOption {
+	return func(edge *conditionalEdge) {
+		edge.label = label
+	}
+}
+
 // conditionalEdge represents an edge with an optional condition.
 type conditionalEdge struct {
 	to        string
+	label     string
 	condition EdgeCondition // nil means always follow this edge
+	// selector and cases make this a switch case edge, taken when the selected key is in cases.
+	selector *switchSelector
+	cases    []string
+}
+
+// conditional reports whether the edge is only followed when its condition or switch case matches.
+func (e conditionalEdge) conditional() bool {
+	return e.condition != nil || e.selector != nil
+}
+
+// switchSelector is shared by the case edges of one AddSwitch, so the executor can run
+// the selector once per step no matter how many cases there are.
+type switchSelector struct {
+	selector func(ctx context.Context, state State) string
 }
 
 // Graph represents a directed graph of processing nodes. Cycles are allowed.
@@ -59,6 +145,11 @@ type Graph struct {
 	maxSteps    int   // maximum number of node execution steps (default 1000)
 	middlewares []Middleware
 	err         error // accumulated error for builder pattern
+
+	hooks              Hooks
+	stateHistory       bool
+	checkpointer       Checkpointer
+	unreachableHandler func(nodes []string) error
 }
 
 // NewGraph creates a new empty Graph.
@@ -77,9 +168,9 @@ func NewGraph(opts ...Option) *Graph {
 	return g
 }
 
-// AddNode adds a named node with its handler to the graph.
+// AddNode adds a named node with its handler to the graph. Options can configure the node.
 // Returns the graph for chaining. Check error with Compile().
-func (g *Graph) AddNode(name string, handler Handler) *Graph {
+func (g *Graph) AddNode(name string, handler Handler, opts ...NodeOption) *Graph {
 	if g.err != nil {
 		return g
 	}
@@ -87,10 +178,35 @@ func (g *Graph) AddNode(name string, handler Handler) *Graph {
 		g.err = fmt.Errorf("graph: node %s already exists", name)
 		return g
 	}
+	var o nodeOptions
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		opt(&o)
+	}
+	if o.timeout > 0 && handler != nil {
+		handler = timeoutHandler(o.timeout, handler)
+	}
 	g.nodes[name] = handler
 	return g
 }
 
+// timeoutHandler wraps a handler so that each invocation runs under its own deadline.
+// Errors are reported as node timeouts only when the node's own deadline expired; a
+// cancelled or expired parent context is returned unchanged.
+func timeoutHandler(d time.Duration, next Handler) Handler {
+	return func(parent context.Context, state State) (State, error) {
+		ctx, cancel := context.WithTimeout(parent, d)
+		defer cancel()
+		nextState, err := next(ctx, state)
+		if err != nil && parent.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out after %s: %w", d, err)
+		}
+		return nextState, err
+	}
+}
+
 // AddEdge adds a directed edge from one node to another. Options can configure the edge.
 // Returns the graph for chaining. Check error with Compile().
 func (g *Graph) AddEdge(from, to string, opts ...EdgeOption) *Graph {
@@ -114,6 +230,44 @@ func (g *Graph) AddEdge(from, to string, opts ...EdgeOption) *Graph {
 	return g
 }
 
+// AddSwitch routes from a node to exactly one target chosen by selector. The selected
+// case key is looked up in cases to find the target node; when no case matches, the
+// edge to defaultTo is taken, or execution fails if defaultTo is empty. Edges are
+// evaluated in order and the first match wins, so at most one branch fires. The selector
+// runs once per step, so nondeterministic selectors such as model classifiers are safe.
+// The switch should be the only set of edges leaving from. Returns the graph for chaining.
+// Check error with Compile().
+func (g *Graph) AddSwitch(from string, selector func(ctx context.Context, state State) string, cases map[string]string, defaultTo string) *Graph {
+	if g.err != nil {
+		return g
+	}
+	if selector == nil {
+		g.err = fmt.Errorf("graph: switch from %s has no selector", from)
+		return g
+	}
+	keysByTarget := make(map[string][]string)
+	for key, to := range cases {
+		if to != defaultTo {
+			keysByTarget[to] = append(keysByTarget[to], key)
+		}
+	}
+	targets := make([]string, 0, len(keysByTarget))
+	for to := range keysByTarget {
+		targets = append(targets, to)
+	}
+	sort.Strings(targets)
+	sw := &switchSelector{selector: selector}
+	for _, to := range targets {
+		keys := keysByTarget[to]
+		sort.Strings(keys)
+		g.AddEdge(from, to, WithEdgeLabel(strings.Join(keys, ", ")), withSwitchCases(sw, keys))
+	}
+	if defaultTo != "" {
+		g.AddEdge(from, defaultTo, WithEdgeLabel("default"))
+	}
+	return g
+}
+
 // SetEntryPoint marks a node as the entry point.
 // Returns the graph for chaining. Check error with Compile().
 func (g *Graph) SetEntryPoint(start string) *Graph {
@@ -172,12 +326,10 @@ func (g *Graph) validate() error {
 	return nil
 }
 
-// ensureReachable verifies that the finish node can be reached from the entry node.
-func (g *Graph) ensureReachable() error {
-	if g.entryPoint == g.finishPoint {
-		return nil
-	}
-	queue := []string{g.entryPoint}
+// walk returns the nodes reachable from start in breadth-first order, following edges in insertion order.
+func (g *Graph) walk(start string) []string {
+	var order []string
+	queue := []string{start}
 	visited := make(map[string]bool, len(g.nodes))
 	for len(queue) > 0 {
 		node := queue[0]
@@ -186,14 +338,44 @@ func (g *Graph) ensureReachable() error {
 			continue
 		}
 		visited[node] = true
-		if node == g.finishPoint {
-			return nil
-		}
+		order = append(order, node)
 		for _, edge := range g.edges[node] {
 			queue = append(queue, edge.to)
 		}
 	}
-	return fmt.Errorf("graph: finish node not reachable: %s", g.finishPoint)
+	return order
+}
+
+// reachableFrom returns the set of nodes reachable from the given node by following edges.
+func (g *Graph) reachableFrom(start string) map[string]bool {
+	order := g.walk(start)
+	visited := make(map[string]bool, len(order))
+	for _, node := range order {
+		visited[node] = true
+	}
+	return visited
+}
+
+// ensureReachable verifies that the finish node can be reached from the entry node.
+func (g *Graph) ensureReachable() error {
+	if !g.reachableFrom(g.entryPoint)[g.finishPoint] {
+		return fmt.Errorf("graph: finish node not reachable: %s", g.finishPoint)
+	}
+	return nil
+}
+
+// UnreachableNodes returns the sorted names of nodes that cannot be reached from the entry point,
+// so wiring mistakes can be caught before Compile. If no entry point is set, every node is reported.
+func (g *Graph) UnreachableNodes() []string {
+	visited := g.reachableFrom(g.entryPoint)
+	var nodes []string
+	for name := range g.nodes {
+		if !visited[name] {
+			nodes = append(nodes, name)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
 }
 
 // Compile validates and compiles the graph into an Executor.
@@ -206,5 +388,17 @@ func (g *Graph) Compile() (*Executor, error) {
 	if err := g.ensureReachable(); err != nil {
 		return nil, err
 	}
+	if g.checkpointer != nil {
+		if err := g.checkFanOut(); err != nil {
+			return nil, err
+		}
+	}
+	if g.unreachableHandler != nil {
+		if nodes := g.UnreachableNodes(); len(nodes) > 0 {
+			if err := g.unreachableHandler(nodes); err != nil {
+				return nil, fmt.Errorf("graph: unreachable nodes: %w", err)
+			}
+		}
+	}
 	return NewExecutor(g), nil
 }
@@ -2,6 +2,7 @@ package graph
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -1056,3 +1057,579 @@ func TestGraphComplexTopologySerial(t *testing.T) {
 		t.Errorf("start should execute before final, got start at %d, final at %d", startIdx, finalIdx)
 	}
 }
+
+func TestGraphUnreachableHandler(t *testing.T) {
+	build := func(opts ...Option) *Graph {
+		g := NewGraph(opts...)
+		_ = g.AddNode("A", stepHandler("A"))
+		_ = g.AddNode("B", stepHandler("B"))
+		_ = g.AddNode("orphan", stepHandler("orphan"))
+		_ = g.AddNode("dangling", stepHandler("dangling"))
+		_ = g.AddEdge("A", "B")
+		_ = g.AddEdge("orphan", "dangling")
+		_ = g.SetEntryPoint("A")
+		_ = g.SetFinishPoint("B")
+		return g
+	}
+
+	t.Run("error", func(t *testing.T) {
+		var reported []string
+		g := build(WithUnreachableHandler(func(nodes []string) error {
+			reported = nodes
+			return fmt.Errorf("%v", nodes)
+		}))
+		if _, err := g.Compile(); err == nil || !strings.Contains(err.Error(), "unreachable nodes") {
+			t.Fatalf("expected unreachable nodes error, got %v", err)
+		}
+		if !reflect.DeepEqual(reported, []string{"dangling", "orphan"}) {
+			t.Fatalf("unexpected unreachable nodes: %v", reported)
+		}
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		var reported []string
+		g := build(WithUnreachableHandler(func(nodes []string) error {
+			reported = nodes
+			return nil
+		}))
+		if _, err := g.Compile(); err != nil {
+			t.Fatalf("compile error: %v", err)
+		}
+		if len(reported) != 2 {
+			t.Fatalf("expected 2 unreachable nodes, got %v", reported)
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		if _, err := build().Compile(); err != nil {
+			t.Fatalf("compile error: %v", err)
+		}
+	})
+}
+
+func TestExecutorPlan(t *testing.T) {
+	g := NewGraph()
+	for _, name := range []string{"start", "left", "right", "leftChild", "join", "end"} {
+		_ = g.AddNode(name, stepHandler(name))
+	}
+	_ = g.AddEdge("start", "left")
+	_ = g.AddEdge("start", "right")
+	_ = g.AddEdge("left", "leftChild")
+	_ = g.AddEdge("right", "join")
+	_ = g.AddEdge("leftChild", "join")
+	_ = g.AddEdge("join", "end")
+	_ = g.SetEntryPoint("start")
+	_ = g.SetFinishPoint("end")
+
+	executor, err := g.Compile()
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	want := []string{"start", "left", "right", "leftChild", "join", "end"}
+	if got := executor.Plan(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Plan() = %v; want %v", got, want)
+	}
+}
+
+func TestExecutorExecuteStream(t *testing.T) {
+	g := NewGraph()
+	_ = g.AddNode("A", stepHandler("A"))
+	_ = g.AddNode("B", stepHandler("B"))
+	_ = g.AddNode("C", stepHandler("C"))
+	_ = g.AddEdge("A", "B")
+	_ = g.AddEdge("B", "C")
+	_ = g.SetEntryPoint("A")
+	_ = g.SetFinishPoint("C")
+
+	executor, err := g.Compile()
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	var got []string
+	var last Event
+	for ev := range executor.ExecuteStream(context.Background(), State{}) {
+		if ev.Type == EventEdge {
+			got = append(got, fmt.Sprintf("%s:%s->%s", ev.Type, ev.Node, ev.To))
+		} else {
+			got = append(got, fmt.Sprintf("%s:%s", ev.Type, ev.Node))
+		}
+		last = ev
+	}
+	want := []string{
+		"node_start:A", "node_end:A", "edge:A->B",
+		"node_start:B", "node_end:B", "edge:B->C",
+		"node_start:C", "node_end:C", "graph_end:",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("events = %v; want %v", got, want)
+	}
+	if last.Err != nil {
+		t.Fatalf("unexpected error: %v", last.Err)
+	}
+	if steps := getStringSlice(last.State[stepsKey]); !reflect.DeepEqual(steps, []string{"A", "B", "C"}) {
+		t.Fatalf("final steps = %v", steps)
+	}
+}
+
+func TestGraphNodeTimeout(t *testing.T) {
+	slow := func(ctx context.Context, state State) (State, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return state, nil
+		}
+	}
+	g := NewGraph()
+	_ = g.AddNode("A", stepHandler("A"))
+	_ = g.AddNode("slow", slow, WithNodeTimeout(10*time.Millisecond))
+	_ = g.AddEdge("A", "slow")
+	_ = g.SetEntryPoint("A")
+	_ = g.SetFinishPoint("slow")
+
+	executor, err := g.Compile()
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	start := time.Now()
+	_, err = executor.Execute(context.Background(), State{})
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "node slow") || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected error to identify the timed out node, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("timeout not enforced, took %v", elapsed)
+	}
+}
+
+func TestGraphNodeTimeoutParentDeadline(t *testing.T) {
+	slow := func(ctx context.Context, state State) (State, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	g := NewGraph()
+	_ = g.AddNode("slow", slow, WithNodeTimeout(time.Second))
+	_ = g.SetEntryPoint("slow")
+	_ = g.SetFinishPoint("slow")
+
+	executor, err := g.Compile()
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = executor.Execute(ctx, State{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("parent deadline reported as node timeout: %v", err)
+	}
+}
+
+func TestGraphNodeTimeoutIsPerInvocation(t *testing.T) {
+	var calls int
+	handler := func(ctx context.Context, state State) (State, error) {
+		calls++
+		time.Sleep(5 * time.Millisecond)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		next := state.Clone()
+		next[valueKey] = calls
+		return next, nil
+	}
+	g := NewGraph(WithParallel(false))
+	_ = g.AddNode("loop", handler, WithNodeTimeout(50*time.Millisecond))
+	_ = g.AddNode("done", stepHandler("done"))
+	_ = g.AddEdge("loop", "loop", WithEdgeCondition(func(ctx context.Context, state State) bool {
+		return state[valueKey].(int) < 20
+	}))
+	_ = g.AddEdge("loop", "done", WithEdgeCondition(func(ctx context.Context, state State) bool {
+		return state[valueKey].(int) >= 20
+	}))
+	_ = g.SetEntryPoint("loop")
+	_ = g.SetFinishPoint("done")
+
+	executor, err := g.Compile()
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	if _, err := executor.Execute(context.Background(), State{}); err != nil {
+		t.Fatalf("run error: %v", err)
+	}
+}
+
+func TestGraphHooks(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+	boom := fmt.Errorf("boom")
+	g := NewGraph(WithHooks(Hooks{
+		OnNodeStart: func(ctx context.Context, node string, state State) {
+			record("start:" + node)
+		},
+		OnNodeEnd: func(ctx context.Context, node string, state State, err error, elapsed time.Duration) {
+			if elapsed < 0 {
+				t.Errorf("negative elapsed time for %s", node)
+			}
+			if err != nil {
+				record("error:" + node)
+				return
+			}
+			record("end:" + node)
+		},
+	}))
+	_ = g.AddNode("A", stepHandler("A"))
+	_ = g.AddNode("B", func(ctx context.Context, state State) (State, error) {
+		return nil, boom
+	})
+	_ = g.AddEdge("A", "B")
+	_ = g.SetEntryPoint("A")
+	_ = g.SetFinishPoint("B")
+
+	executor, err := g.Compile()
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	if _, err := executor.Execute(context.Background(), State{}); !errors.Is(err, boom) {
+		t.Fatalf("expected boom error, got %v", err)
+	}
+	want := []string{"start:A", "end:A", "start:B", "error:B"}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("hook events = %v; want %v", events, want)
+	}
+}
+
+func TestGraphEdgeHook(t *testing.T) {
+	var edges []string
+	g := NewGraph(WithHooks(Hooks{
+		OnEdge: func(ctx context.Context, from, to string) {
+			edges = append(edges, from+"->"+to)
+		},
+	}))
+	_ = g.AddNode("start", stepHandler("start"))
+	_ = g.AddNode("left", stepHandler("left"))
+	_ = g.AddNode("right", stepHandler("right"))
+	_ = g.AddNode("end", stepHandler("end"))
+	_ = g.AddEdge("start", "left", WithEdgeCondition(func(_ context.Context, state State) bool {
+		return false
+	}))
+	_ = g.AddEdge("start", "right", WithEdgeCondition(func(_ context.Context, state State) bool {
+		return true
+	}))
+	_ = g.AddEdge("left", "end")
+	_ = g.AddEdge("right", "end")
+	_ = g.SetEntryPoint("start")
+	_ = g.SetFinishPoint("end")
+
+	executor, err := g.Compile()
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	if _, err := executor.Execute(context.Background(), State{}); err != nil {
+		t.Fatalf("execution error: %v", err)
+	}
+	want := []string{"start->right", "right->end"}
+	if !reflect.DeepEqual(edges, want) {
+		t.Fatalf("edges = %v; want %v", edges, want)
+	}
+}
+
+func TestGraphAddSwitch(t *testing.T) {
+	build := func(topic, defaultTo string) (*Executor, error) {
+		g := NewGraph()
+		_ = g.AddNode("classify", func(ctx context.Context, state State) (State, error) {
+			next := appendStep(state, "classify")
+			next["topic"] = topic
+			return next, nil
+		})
+		_ = g.AddNode("billing", stepHandler("billing"))
+		_ = g.AddNode("support", stepHandler("support"))
+		_ = g.AddNode("fallback", stepHandler("fallback"))
+		_ = g.AddNode("done", stepHandler("done"))
+		_ = g.AddSwitch("classify", func(_ context.Context, state State) string {
+			topic, _ := state["topic"].(string)
+			return topic
+		}, map[string]string{
+			"invoice": "billing",
+			"refund":  "billing",
+			"bug":     "support",
+		}, defaultTo)
+		for _, node := range []string{"billing", "support", "fallback"} {
+			_ = g.AddEdge(node, "done")
+		}
+		_ = g.SetEntryPoint("classify")
+		_ = g.SetFinishPoint("done")
+		return g.Compile()
+	}
+	tests := []struct {
+		name      string
+		topic     string
+		defaultTo string
+		want      []string
+		wantErr   bool
+	}{
+		{name: "case", topic: "refund", defaultTo: "fallback", want: []string{"classify", "billing", "done"}},
+		{name: "other case", topic: "bug", defaultTo: "fallback", want: []string{"classify", "support", "done"}},
+		{name: "default", topic: "other", defaultTo: "fallback", want: []string{"classify", "fallback", "done"}},
+		{name: "no default", topic: "other", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor, err := build(tt.topic, tt.defaultTo)
+			if err != nil {
+				t.Fatalf("compile error: %v", err)
+			}
+			state, err := executor.Execute(context.Background(), State{})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error when no case matches and there is no default")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("execution error: %v", err)
+			}
+			if got, _ := state[stepsKey].([]string); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("steps = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphAddSwitchUnknownTarget(t *testing.T) {
+	g := NewGraph()
+	_ = g.AddNode("start", stepHandler("start"))
+	_ = g.AddSwitch("start", func(context.Context, State) string { return "x" }, map[string]string{"x": "missing"}, "")
+	_ = g.SetEntryPoint("start")
+	_ = g.SetFinishPoint("start")
+	if _, err := g.Compile(); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("expected unknown target error, got %v", err)
+	}
+}
+
+func TestGraphUnreachableNodes(t *testing.T) {
+	g := NewGraph()
+	_ = g.AddNode("start", stepHandler("start"))
+	_ = g.AddNode("end", stepHandler("end"))
+	_ = g.AddNode("orphan", stepHandler("orphan"))
+	_ = g.AddNode("island", stepHandler("island"))
+	_ = g.AddEdge("start", "end")
+	_ = g.AddEdge("orphan", "island")
+	if got, want := g.UnreachableNodes(), []string{"end", "island", "orphan", "start"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("UnreachableNodes() without entry point = %v; want %v", got, want)
+	}
+	_ = g.SetEntryPoint("start")
+	if got, want := g.UnreachableNodes(), []string{"island", "orphan"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("UnreachableNodes() = %v; want %v", got, want)
+	}
+}
+
+func TestGraphStateHistory(t *testing.T) {
+	g := NewGraph(WithStateHistory())
+	g.AddNode("loop", func(ctx context.Context, state State) (State, error) {
+		next := appendStep(state, "loop")
+		val, _ := next[valueKey].(int)
+		next[valueKey] = val + 1
+		return next, nil
+	})
+	g.AddNode("exit", stepHandler("exit"))
+	g.AddEdge("loop", "loop", WithEdgeCondition(func(_ context.Context, state State) bool {
+		val, _ := state[valueKey].(int)
+		return val < 2
+	}))
+	g.AddEdge("loop", "exit", WithEdgeCondition(func(_ context.Context, state State) bool {
+		val, _ := state[valueKey].(int)
+		return val >= 2
+	}))
+	g.SetEntryPoint("loop")
+	g.SetFinishPoint("exit")
+
+	executor, err := g.Compile()
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	// Leave spare capacity so every node appends into the same backing array.
+	result, err := executor.Execute(context.Background(), State{stepsKey: make([]string, 0, 8)})
+	if err != nil {
+		t.Fatalf("run error: %v", err)
+	}
+	// Snapshots are deep copies, so mutating the result must not change them.
+	result[stepsKey].([]string)[0] = "mutated"
+
+	want := []StateSnapshot{
+		{Node: "loop", Step: 1, State: State{stepsKey: []string{"loop"}, valueKey: 1}},
+		{Node: "loop", Step: 2, State: State{stepsKey: []string{"loop", "loop"}, valueKey: 2}},
+		{Node: "exit", Step: 3, State: State{stepsKey: []string{"loop", "loop", "exit"}, valueKey: 2}},
+	}
+	if got := executor.History(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("History() = %v; want %v", got, want)
+	}
+}
+
+func TestGraphStateHistoryParallel(t *testing.T) {
+	g := NewGraph(WithStateHistory())
+	g.AddNode("start", stepHandler("start"))
+	g.AddNode("a", func(ctx context.Context, state State) (State, error) {
+		next := state.Clone()
+		next["a"] = true
+		return next, nil
+	})
+	g.AddNode("b", func(ctx context.Context, state State) (State, error) {
+		next := state.Clone()
+		next["b"] = true
+		return next, nil
+	})
+	g.AddNode("join", stepHandler("join"))
+	g.AddEdge("start", "a")
+	g.AddEdge("start", "b")
+	g.AddEdge("a", "join")
+	g.AddEdge("b", "join")
+	g.SetEntryPoint("start")
+	g.SetFinishPoint("join")
+
+	executor, err := g.Compile()
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	if _, err := executor.Execute(context.Background(), State{}); err != nil {
+		t.Fatalf("run error: %v", err)
+	}
+
+	var nodes []string
+	for i, snapshot := range executor.History() {
+		if snapshot.Step != i+1 {
+			t.Fatalf("snapshot %d has step %d", i, snapshot.Step)
+		}
+		nodes = append(nodes, snapshot.Node)
+	}
+	if want := []string{"start", "a", "b", "join"}; !reflect.DeepEqual(nodes, want) {
+		t.Fatalf("history nodes = %v; want %v", nodes, want)
+	}
+	if final := executor.History()[3].State; final["a"] != true || final["b"] != true {
+		t.Fatalf("join snapshot = %v; want merged branch keys", final)
+	}
+}
+
+func TestGraphStateHistoryDisabled(t *testing.T) {
+	g := NewGraph()
+	g.AddNode("start", stepHandler("start"))
+	g.SetEntryPoint("start")
+	g.SetFinishPoint("start")
+	executor, err := g.Compile()
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	if _, err := executor.Execute(context.Background(), State{}); err != nil {
+		t.Fatalf("run error: %v", err)
+	}
+	if history := executor.History(); len(history) != 0 {
+		t.Fatalf("History() = %v; want empty", history)
+	}
+}
+
+func TestGraphAddSwitchSelectsOnce(t *testing.T) {
+	for _, defaultTo := range []string{"", "fallback"} {
+		t.Run("default="+defaultTo, func(t *testing.T) {
+			// The selector answers differently on every call, like a sampled model classifier.
+			answers := []string{"bug", "invoice", "other"}
+			calls := 0
+			g := NewGraph()
+			_ = g.AddNode("classify", stepHandler("classify"))
+			_ = g.AddNode("billing", stepHandler("billing"))
+			_ = g.AddNode("support", stepHandler("support"))
+			_ = g.AddNode("fallback", stepHandler("fallback"))
+			_ = g.AddSwitch("classify", func(_ context.Context, _ State) string {
+				answer := answers[calls%len(answers)]
+				calls++
+				return answer
+			}, map[string]string{
+				"invoice": "billing",
+				"bug":     "support",
+			}, defaultTo)
+			_ = g.SetEntryPoint("classify")
+			_ = g.SetFinishPoint("support")
+			executor, err := g.Compile()
+			if err != nil {
+				t.Fatalf("compile error: %v", err)
+			}
+			state, err := executor.Execute(context.Background(), State{})
+			if err != nil {
+				t.Fatalf("execution error: %v", err)
+			}
+			if calls != 1 {
+				t.Fatalf("selector called %d times; want 1", calls)
+			}
+			if got, want := state[stepsKey].([]string), []string{"classify", "support"}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("steps = %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestExecutorExecuteStreamCancel(t *testing.T) {
+	newExecutor := func(t *testing.T, started chan<- struct{}) *Executor {
+		g := NewGraph()
+		_ = g.AddNode("A", stepHandler("A"))
+		_ = g.AddNode("B", func(ctx context.Context, state State) (State, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		_ = g.AddEdge("A", "B")
+		_ = g.SetEntryPoint("A")
+		_ = g.SetFinishPoint("B")
+		executor, err := g.Compile()
+		if err != nil {
+			t.Fatalf("compile error: %v", err)
+		}
+		return executor
+	}
+	checkEnd := func(t *testing.T, last Event) {
+		t.Helper()
+		if last.Type != EventGraphEnd {
+			t.Fatalf("last event = %s; want %s", last.Type, EventGraphEnd)
+		}
+		if !errors.Is(last.Err, context.Canceled) {
+			t.Fatalf("final error = %v; want %v", last.Err, context.Canceled)
+		}
+	}
+
+	t.Run("draining consumer", func(t *testing.T) {
+		started := make(chan struct{})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := newExecutor(t, started).ExecuteStream(ctx, State{})
+		var last Event
+		for ev := range events {
+			if ev.Type == EventNodeStart && ev.Node == "B" {
+				<-started
+				cancel()
+			}
+			last = ev
+		}
+		checkEnd(t, last)
+	})
+
+	t.Run("consumer reads after cancel", func(t *testing.T) {
+		started := make(chan struct{})
+		ctx, cancel := context.WithCancel(context.Background())
+		events := newExecutor(t, started).ExecuteStream(ctx, State{})
+		<-started
+		cancel()
+		var last Event
+		for ev := range events {
+			last = ev
+		}
+		checkEnd(t, last)
+	})
+}
@@ -1,12 +1,24 @@
 package graph
 
-import "maps"
+import (
+	"maps"
+	"reflect"
+)
 
 // State represents the mutable data that flows through the graph.
 // It is implemented as a map of string keys to arbitrary values.
 // Handlers should treat State as immutable and always return a cloned instance.
 type State map[string]any
 
+// StateSnapshot is a copy of the state produced by a node execution.
+// Step is the 1-based position of the execution, so nodes revisited in cyclic
+// graphs can be told apart.
+type StateSnapshot struct {
+	Node  string
+	State State
+	Step  int
+}
+
 // Clone performs a shallow copy using maps.Clone so callers can mutate without
 // affecting the original map (nested references are shared intentionally).
 func (s State) Clone() State {
@@ -15,3 +27,50 @@ func (s State) Clone() State {
 	}
 	return State(maps.Clone(map[string]any(s)))
 }
+
+// deepClone copies the state along with any nested maps, slices and arrays.
+// Pointers, channels and functions are still shared.
+func (s State) deepClone() State {
+	if s == nil {
+		return State{}
+	}
+	return deepCopy(reflect.ValueOf(s)).Interface().(State)
+}
+
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(deepCopy(v.Elem()))
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,111 @@
+package blades
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// LoggingOption configures LoggingMiddleware.
+type LoggingOption func(*loggingMiddleware)
+
+// WithLogContent includes prompt and output text in log records. Content is
+// redacted by default; enable this only for debugging.
+func WithLogContent() LoggingOption {
+	return func(m *loggingMiddleware) {
+		m.content = true
+	}
+}
+
+// LoggingMiddleware returns a Middleware that logs the start and end of each run
+// with the agent, model, message count, elapsed time, token usage when reported,
+// and any error.
+func LoggingMiddleware(logger *slog.Logger, opts ...LoggingOption) Middleware {
+	return func(next Runnable) Runnable {
+		m := &loggingMiddleware{next: next, logger: logger}
+		for _, opt := range opts {
+			opt(m)
+		}
+		return m
+	}
+}
+
+type loggingMiddleware struct {
+	next    Runnable
+	logger  *slog.Logger
+	content bool
+}
+
+func (m *loggingMiddleware) Run(ctx context.Context, p *Prompt, opts ...ModelOption) (*Message, error) {
+	start := m.logStart(ctx, "run", p)
+	output, err := m.next.Run(ctx, p, opts...)
+	m.logEnd(ctx, "run", start, output, err)
+	return output, err
+}
+
+func (m *loggingMiddleware) RunStream(ctx context.Context, p *Prompt, opts ...ModelOption) (Streamable[*Message], error) {
+	start := m.logStart(ctx, "stream", p)
+	stream, err := m.next.RunStream(ctx, p, opts...)
+	if err != nil {
+		m.logEnd(ctx, "stream", start, nil, err)
+		return nil, err
+	}
+	pipe := NewStreamPipe[*Message]()
+	pipe.Go(func() error {
+		var last *Message
+		for stream.Next() {
+			output, err := stream.Current()
+			if err != nil {
+				m.logEnd(ctx, "stream", start, last, err)
+				return err
+			}
+			last = output
+			pipe.Send(output)
+		}
+		m.logEnd(ctx, "stream", start, last, nil)
+		return nil
+	})
+	return pipe, nil
+}
+
+func (m *loggingMiddleware) attrs(ctx context.Context) []slog.Attr {
+	var attrs []slog.Attr
+	if agent, ok := FromContext(ctx); ok {
+		attrs = append(attrs, slog.String("agent", agent.Name))
+		if agent.Model != "" {
+			attrs = append(attrs, slog.String("model", agent.Model))
+		}
+	}
+	return attrs
+}
+
+func (m *loggingMiddleware) logStart(ctx context.Context, kind string, p *Prompt) time.Time {
+	attrs := append(m.attrs(ctx), slog.Int("messages", len(p.Messages)))
+	if m.content {
+		attrs = append(attrs, slog.String("prompt", p.String()))
+	}
+	m.logger.LogAttrs(ctx, slog.LevelInfo, kind+" started", attrs...)
+	return time.Now()
+}
+
+func (m *loggingMiddleware) logEnd(ctx context.Context, kind string, start time.Time, output *Message, err error) {
+	attrs := append(m.attrs(ctx), slog.Duration("elapsed", time.Since(start)))
+	if output != nil {
+		if output.Usage != nil {
+			attrs = append(attrs,
+				slog.Int("prompt_tokens", output.Usage.PromptTokens),
+				slog.Int("completion_tokens", output.Usage.CompletionTokens),
+				slog.Int("total_tokens", output.Usage.TotalTokens),
+			)
+		}
+		if m.content {
+			attrs = append(attrs, slog.String("output", output.Text()))
+		}
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+		m.logger.LogAttrs(ctx, slog.LevelError, kind+" failed", attrs...)
+		return
+	}
+	m.logger.LogAttrs(ctx, slog.LevelInfo, kind+" finished", attrs...)
+}
@@ -0,0 +1,47 @@
+package blades
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	next := &HandleFunc{
+		Handle: func(ctx context.Context, p *Prompt, _ ...ModelOption) (*Message, error) {
+			output := AssistantMessage("secret answer")
+			output.Usage = &Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5}
+			return output, nil
+		},
+	}
+	tests := []struct {
+		name        string
+		opts        []LoggingOption
+		wantContent bool
+	}{
+		{name: "redacted"},
+		{name: "content", opts: []LoggingOption{WithLogContent()}, wantContent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&buf, nil))
+			ctx := NewContext(context.Background(), &AgentContext{Name: "test", Model: "gpt-test"})
+			h := LoggingMiddleware(logger, tt.opts...)(next)
+			if _, err := h.Run(ctx, NewPrompt(UserMessage("secret question"))); err != nil {
+				t.Fatalf("Run() error: %v", err)
+			}
+			out := buf.String()
+			for _, want := range []string{"run started", "run finished", "model=gpt-test", "messages=1", "elapsed=", "total_tokens=5"} {
+				if !strings.Contains(out, want) {
+					t.Errorf("log output missing %q:\n%s", want, out)
+				}
+			}
+			if got := strings.Contains(out, "secret"); got != tt.wantContent {
+				t.Errorf("log output contains content = %v; want %v:\n%s", got, tt.wantContent, out)
+			}
+		})
+	}
+}
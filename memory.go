@@ -0,0 +1,194 @@
+package blades
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Memory stores conversation history across agent runs, keyed by session ID.
+type Memory interface {
+	// Load returns the prior messages recorded for the session.
+	Load(ctx context.Context, sessionID string) ([]*Message, error)
+	// Append records new messages for the session.
+	Append(ctx context.Context, sessionID string, messages []*Message) error
+}
+
+// WindowMemory is an in-memory Memory that keeps the most recent turns of each session.
+// A turn starts at a user message and includes the messages that follow it.
+type WindowMemory struct {
+	mu       sync.Mutex
+	maxTurns int
+	sessions map[string][]*Message
+}
+
+// NewWindowMemory creates a WindowMemory that keeps at most maxTurns turns per session.
+// A non-positive maxTurns keeps the full history.
+func NewWindowMemory(maxTurns int) *WindowMemory {
+	return &WindowMemory{
+		maxTurns: maxTurns,
+		sessions: make(map[string][]*Message),
+	}
+}
+
+// Load returns a copy of the messages recorded for the session.
+func (m *WindowMemory) Load(ctx context.Context, sessionID string) ([]*Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.sessions[sessionID]), nil
+}
+
+// Append records messages for the session and drops turns beyond the window.
+func (m *WindowMemory) Append(ctx context.Context, sessionID string, messages []*Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	history := append(m.sessions[sessionID], messages...)
+	m.sessions[sessionID] = m.window(history)
+	return nil
+}
+
+// window trims history to start at the maxTurns-th most recent user message.
+func (m *WindowMemory) window(history []*Message) []*Message {
+	if m.maxTurns <= 0 {
+		return history
+	}
+	if start := turnStart(history, m.maxTurns); start > 0 {
+		return slices.Clone(history[start:])
+	}
+	return history
+}
+
+// turnStart returns the index at which the last n turns of history begin, or 0
+// when history holds n turns or fewer.
+func turnStart(history []*Message, n int) int {
+	turns := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Role != RoleUser {
+			continue
+		}
+		if turns++; turns == n {
+			return i
+		}
+	}
+	return 0
+}
+
+// SummaryTrigger reports whether the unsummarized messages of a session should be
+// compressed into the summary.
+type SummaryTrigger func(messages []*Message) bool
+
+// MaxTurnsTrigger triggers summarization when more than n turns are stored.
+func MaxTurnsTrigger(n int) SummaryTrigger {
+	return func(messages []*Message) bool {
+		turns := 0
+		for _, m := range messages {
+			if m.Role == RoleUser {
+				turns++
+			}
+		}
+		return turns > n
+	}
+}
+
+// MaxTokensTrigger triggers summarization when the stored messages exceed n
+// estimated tokens. A nil counter uses DefaultTokenCounter.
+func MaxTokensTrigger(n int, counter TokenCounter) SummaryTrigger {
+	return func(messages []*Message) bool {
+		return EstimateTokens(NewPrompt(messages...), counter) > n
+	}
+}
+
+// summaryInstructions asks the summarizer to fold the older turns into the running summary.
+const summaryInstructions = "Summarize the conversation so far into a concise summary that preserves facts, decisions and open questions. " +
+	"If a previous summary is included, extend it with the new messages."
+
+// SummaryMemory is an in-memory Memory that compresses older turns into a summary.
+// When the trigger fires, every turn except the most recent keepTurns is summarized
+// by the summarizer into a single system message. Load returns the summary followed
+// by the recent turns verbatim.
+type SummaryMemory struct {
+	mu         sync.Mutex
+	summarizer Runnable
+	trigger    SummaryTrigger
+	keepTurns  int
+	sessions   map[string]*summarySession
+}
+
+type summarySession struct {
+	summary *Message
+	recent  []*Message
+	// summarizing is set while the summarizer runs for the session, so that only one runs at a time.
+	summarizing bool
+}
+
+// NewSummaryMemory creates a SummaryMemory that summarizes with summarizer when
+// trigger fires, keeping the most recent keepTurns turns verbatim.
+func NewSummaryMemory(summarizer Runnable, trigger SummaryTrigger, keepTurns int) *SummaryMemory {
+	return &SummaryMemory{
+		summarizer: summarizer,
+		trigger:    trigger,
+		keepTurns:  keepTurns,
+		sessions:   make(map[string]*summarySession),
+	}
+}
+
+// Load returns the summary, if any, followed by the recent messages of the session.
+func (m *SummaryMemory) Load(ctx context.Context, sessionID string) ([]*Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[sessionID]
+	if !ok {
+		return nil, nil
+	}
+	messages := make([]*Message, 0, len(s.recent)+1)
+	if s.summary != nil {
+		messages = append(messages, s.summary)
+	}
+	return append(messages, s.recent...), nil
+}
+
+// Append records messages for the session and summarizes older turns when the trigger fires.
+// The summarizer runs without holding the memory lock, so other sessions, and loads of this
+// one, are not blocked by the model call. Messages appended to the session meanwhile are kept.
+func (m *SummaryMemory) Append(ctx context.Context, sessionID string, messages []*Message) error {
+	m.mu.Lock()
+	s, ok := m.sessions[sessionID]
+	if !ok {
+		s = &summarySession{}
+		m.sessions[sessionID] = s
+	}
+	s.recent = append(s.recent, messages...)
+	if s.summarizing || !m.trigger(s.recent) {
+		m.mu.Unlock()
+		return nil
+	}
+	start := len(s.recent)
+	if m.keepTurns > 0 {
+		start = turnStart(s.recent, m.keepTurns)
+	}
+	if start == 0 {
+		m.mu.Unlock()
+		return nil
+	}
+	prompt := NewPrompt(SystemMessage(summaryInstructions))
+	if s.summary != nil {
+		prompt = prompt.Append(s.summary)
+	}
+	prompt = prompt.Append(s.recent[:start]...)
+	s.summarizing = true
+	m.mu.Unlock()
+
+	summary, err := m.summarizer.Run(ctx, prompt)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s.summarizing = false
+	if err != nil {
+		return fmt.Errorf("memory: summarize: %w", err)
+	}
+	s.summary = SystemMessage("Summary of the earlier conversation:\n" + summary.Text())
+	// Only appends happen while summarizing, so the summarized messages are still the prefix.
+	s.recent = slices.Clone(s.recent[start:])
+	return nil
+}
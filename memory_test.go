@@ -0,0 +1,167 @@
+package blades
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWindowMemory(t *testing.T) {
+	ctx := context.Background()
+	mem := NewWindowMemory(2)
+	for _, text := range []string{"one", "two", "three"} {
+		if err := mem.Append(ctx, "s1", []*Message{UserMessage(text), AssistantMessage("re: " + text)}); err != nil {
+			t.Fatalf("Append() error: %v", err)
+		}
+	}
+	history, err := mem.Load(ctx, "s1")
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	var got []string
+	for _, m := range history {
+		got = append(got, m.Text())
+	}
+	want := []string{"two", "re: two", "three", "re: three"}
+	if len(got) != len(want) {
+		t.Fatalf("Load() = %q; want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Load() = %q; want %q", got, want)
+		}
+	}
+	if other, _ := mem.Load(ctx, "s2"); len(other) != 0 {
+		t.Fatalf("Load() for another session = %v; want empty", other)
+	}
+}
+
+func TestAgentMemory(t *testing.T) {
+	provider := &mockProvider{text: "ok"}
+	mem := NewWindowMemory(0)
+	agent := NewAgent("test", WithProvider(provider), WithMemory(mem, ""))
+
+	session := NewSession()
+	ctx := NewSessionContext(context.Background(), session)
+	for _, text := range []string{"first", "second"} {
+		if _, err := agent.Run(ctx, NewPrompt(UserMessage(text))); err != nil {
+			t.Fatalf("Run() error: %v", err)
+		}
+	}
+	req := provider.requests[1]
+	if len(req.Messages) != 3 || req.Messages[0].Text() != "first" || req.Messages[1].Text() != "ok" || req.Messages[2].Text() != "second" {
+		t.Fatalf("second request did not include prior turn: %v", req.Messages)
+	}
+	history, _ := mem.Load(ctx, session.ID)
+	if len(history) != 4 {
+		t.Fatalf("memory for session %q has %d messages; want 4", session.ID, len(history))
+	}
+}
+
+func TestSummaryMemory(t *testing.T) {
+	ctx := context.Background()
+	var prompts []*Prompt
+	summarizer := &HandleFunc{
+		Handle: func(ctx context.Context, p *Prompt, _ ...ModelOption) (*Message, error) {
+			prompts = append(prompts, p)
+			return AssistantMessage("summary of " + p.LastOf(RoleAssistant).Text()), nil
+		},
+	}
+	mem := NewSummaryMemory(summarizer, MaxTurnsTrigger(2), 1)
+	for _, text := range []string{"one", "two", "three"} {
+		if err := mem.Append(ctx, "s1", []*Message{UserMessage(text), AssistantMessage("re: " + text)}); err != nil {
+			t.Fatalf("Append() error: %v", err)
+		}
+	}
+	if len(prompts) != 1 {
+		t.Fatalf("summarizer called %d times; want 1", len(prompts))
+	}
+	// The summarizer sees the instructions and the two oldest turns.
+	if got := promptTexts(prompts[0]); len(got) != 5 || got[1] != "one" || got[4] != "re: two" {
+		t.Fatalf("summarizer prompt = %q", got)
+	}
+	history, err := mem.Load(ctx, "s1")
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	got := promptTexts(NewPrompt(history...))
+	want := []string{"Summary of the earlier conversation:\nsummary of re: two", "three", "re: three"}
+	if len(got) != len(want) {
+		t.Fatalf("Load() = %q; want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Load() = %q; want %q", got, want)
+		}
+	}
+	if history[0].Role != RoleSystem {
+		t.Fatalf("summary role = %s; want %s", history[0].Role, RoleSystem)
+	}
+}
+
+func TestSummaryMemoryConcurrent(t *testing.T) {
+	ctx := context.Background()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	summarizer := &HandleFunc{
+		Handle: func(ctx context.Context, p *Prompt, _ ...ModelOption) (*Message, error) {
+			calls.Add(1)
+			close(started)
+			<-release
+			return AssistantMessage("summary"), nil
+		},
+	}
+	mem := NewSummaryMemory(summarizer, MaxTurnsTrigger(1), 1)
+	if err := mem.Append(ctx, "s1", []*Message{UserMessage("one"), AssistantMessage("re: one")}); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- mem.Append(ctx, "s1", []*Message{UserMessage("two"), AssistantMessage("re: two")})
+	}()
+	<-started
+
+	// While s1 is being summarized, other sessions and loads must not wait on it.
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		if err := mem.Append(ctx, "s2", []*Message{UserMessage("hello")}); err != nil {
+			t.Errorf("Append() error: %v", err)
+		}
+		if history, _ := mem.Load(ctx, "s1"); len(history) != 4 {
+			t.Errorf("Load() during summarization returned %d messages; want 4", len(history))
+		}
+		// Appending to s1 again must not start a second summarization.
+		if err := mem.Append(ctx, "s1", []*Message{UserMessage("three"), AssistantMessage("re: three")}); err != nil {
+			t.Errorf("Append() error: %v", err)
+		}
+	}()
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("memory operations blocked while the summarizer was running")
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("summarizer called %d times; want 1", n)
+	}
+	history, err := mem.Load(ctx, "s1")
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	got := promptTexts(NewPrompt(history...))
+	want := []string{"Summary of the earlier conversation:\nsummary", "two", "re: two", "three", "re: three"}
+	if len(got) != len(want) {
+		t.Fatalf("Load() = %q; want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Load() = %q; want %q", got, want)
+		}
+	}
+}
@@ -76,6 +76,22 @@ type Message struct {
 	Parts    []Part            `json:"parts"`
 	Status   Status            `json:"status"`
 	Metadata map[string]string `json:"metadata,omitempty"`
+	Usage    *Usage            `json:"usage,omitempty"`
+}
+
+// Usage reports the tokens consumed to generate a message, as returned by the provider.
+type Usage struct {
+	PromptTokens     int `json:"promptTokens"`
+	CompletionTokens int `json:"completionTokens"`
+	TotalTokens      int `json:"totalTokens"`
+}
+
+// TotalTokens returns the total tokens consumed to generate the message, or 0 if the provider reported no usage.
+func (m *Message) TotalTokens() int {
+	if m.Usage == nil {
+		return 0
+	}
+	return m.Usage.TotalTokens
 }
 
 // Text returns the first text part of the message, or an empty string if none exists.
@@ -34,3 +34,18 @@ func (f *HandleFunc) Run(ctx context.Context, p *Prompt, opts ...ModelOption) (*
 func (f *HandleFunc) RunStream(ctx context.Context, p *Prompt, opts ...ModelOption) (Streamable[*Message], error) {
 	return f.HandleStream(ctx, p, opts...)
 }
+
+// ProviderMiddleware wraps a ModelProvider and returns a new ModelProvider with additional behavior.
+type ProviderMiddleware func(ModelProvider) ModelProvider
+
+// ChainProviderMiddlewares composes provider middlewares into one, applying them in order.
+// The first middleware becomes the outermost wrapper.
+func ChainProviderMiddlewares(mws ...ProviderMiddleware) ProviderMiddleware {
+	return func(next ModelProvider) ModelProvider {
+		p := next
+		for i := len(mws) - 1; i >= 0; i-- { // apply in reverse to make mws[0] outermost
+			p = mws[i](p)
+		}
+		return p
+	}
+}
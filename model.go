@@ -55,6 +55,8 @@ type ModelRequest struct {
 	Messages     []*Message         `json:"messages"`
 	InputSchema  *jsonschema.Schema `json:"inputSchema,omitempty"`
 	OutputSchema *jsonschema.Schema `json:"outputSchema,omitempty"`
+	// ResponseFormat is the output format requested by the agent, if any.
+	ResponseFormat *ResponseFormat `json:"responseFormat,omitempty"`
 }
 
 // ModelResponse is a single assistant message as a result of generation.
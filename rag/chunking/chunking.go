@@ -0,0 +1,45 @@
+package chunking
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Chunker splits text into smaller chunks suitable for indexing.
+type Chunker interface {
+	Split(text string) []string
+}
+
+// splitSentences splits text into trimmed sentences. A sentence ends at a
+// terminal punctuation mark followed by whitespace or the end of the text.
+func splitSentences(text string) []string {
+	var (
+		sentences []string
+		runes     = []rune(text)
+		start     int
+	)
+	for i, r := range runes {
+		if !isSentenceTerminator(r) {
+			continue
+		}
+		if i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+			continue
+		}
+		if s := strings.TrimSpace(string(runes[start : i+1])); s != "" {
+			sentences = append(sentences, s)
+		}
+		start = i + 1
+	}
+	if s := strings.TrimSpace(string(runes[start:])); s != "" {
+		sentences = append(sentences, s)
+	}
+	return sentences
+}
+
+func isSentenceTerminator(r rune) bool {
+	switch r {
+	case '.', '!', '?', '。', '！', '？':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,271 @@
+package chunking
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+var _ Chunker = (*CodeChunker)(nil)
+
+// CodeChunker splits source code on top-level declaration boundaries such as
+// functions and types, packing small declarations together up to maxChars.
+// Oversized declarations are split between lines, avoiding lines that start inside
+// a string literal or comment block, and cut at maxChars only as a last resort.
+//
+// Go ("go") and Python ("python" or "py") are supported; for other languages the
+// code is split between lines only.
+type CodeChunker struct {
+	lang     string
+	maxChars int
+}
+
+// NewCodeChunker creates a CodeChunker for the given language that emits chunks of
+// at most maxChars characters. A non-positive maxChars uses DefaultChunkSize.
+func NewCodeChunker(lang string, maxChars int) *CodeChunker {
+	if maxChars <= 0 {
+		maxChars = DefaultChunkSize
+	}
+	return &CodeChunker{lang: strings.ToLower(lang), maxChars: maxChars}
+}
+
+// codeLine is a source line and the scanner state at its start.
+type codeLine struct {
+	text string
+	// safe reports whether the line starts outside any string literal or comment block.
+	safe bool
+	// decl reports whether the line starts a top-level declaration.
+	decl bool
+}
+
+// Split splits source code into chunks along declaration boundaries.
+func (c *CodeChunker) Split(text string) []string {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	var lines []codeLine
+	switch c.lang {
+	case "go":
+		lines = scanGo(text)
+	case "python", "py":
+		lines = scanPython(text)
+	default:
+		lines = scanPlain(text)
+	}
+	var (
+		chunks  []string
+		current []codeLine
+		size    int
+	)
+	flush := func() {
+		if s := joinLines(current); strings.TrimSpace(s) != "" {
+			chunks = append(chunks, s)
+		}
+		current, size = nil, 0
+	}
+	for _, decl := range c.declarations(lines) {
+		n := utf8.RuneCountInString(joinLines(decl))
+		switch {
+		case n > c.maxChars:
+			flush()
+			chunks = append(chunks, c.splitLines(decl)...)
+			continue
+		case len(current) > 0 && size+1+n > c.maxChars:
+			flush()
+		}
+		// Cap current so appending never writes into the shared lines array.
+		current = append(current[:len(current):len(current)], decl...)
+		size = utf8.RuneCountInString(joinLines(current))
+	}
+	flush()
+	return chunks
+}
+
+// declarations groups lines into declarations, attaching preceding comment and
+// decorator lines to the declaration that follows them.
+func (c *CodeChunker) declarations(lines []codeLine) [][]codeLine {
+	var (
+		decls [][]codeLine
+		start int
+	)
+	for i := range lines {
+		if !lines[i].decl {
+			continue
+		}
+		b := i
+		for b > start && lines[b-1].safe && c.attaches(lines[b-1].text) {
+			b--
+		}
+		if b > start {
+			decls = append(decls, lines[start:b])
+			start = b
+		}
+	}
+	return append(decls, lines[start:])
+}
+
+// attaches reports whether a line belongs to the declaration below it.
+func (c *CodeChunker) attaches(line string) bool {
+	line = strings.TrimSpace(line)
+	switch c.lang {
+	case "go":
+		return strings.HasPrefix(line, "//")
+	case "python", "py":
+		return strings.HasPrefix(line, "#") || strings.HasPrefix(line, "@")
+	}
+	return false
+}
+
+// splitLines packs lines into chunks of at most maxChars, breaking only before
+// safe lines where possible.
+func (c *CodeChunker) splitLines(lines []codeLine) []string {
+	var (
+		chunks  []string
+		current []codeLine
+		size    int
+	)
+	for _, line := range lines {
+		n := utf8.RuneCountInString(line.text) + 1
+		if len(current) > 0 && line.safe && size+n > c.maxChars {
+			chunks = append(chunks, joinLines(current))
+			current, size = nil, 0
+		}
+		current = append(current, line)
+		size += n
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, joinLines(current))
+	}
+	var out []string
+	for _, chunk := range chunks {
+		if utf8.RuneCountInString(chunk) <= c.maxChars {
+			out = append(out, chunk)
+			continue
+		}
+		runes := []rune(chunk)
+		for start := 0; start < len(runes); start += c.maxChars {
+			out = append(out, string(runes[start:min(start+c.maxChars, len(runes))]))
+		}
+	}
+	return out
+}
+
+func joinLines(lines []codeLine) string {
+	texts := make([]string, len(lines))
+	for i, line := range lines {
+		texts[i] = line.text
+	}
+	return strings.Trim(strings.Join(texts, "\n"), "\n")
+}
+
+var goDeclPrefixes = []string{"func ", "func(", "type ", "var ", "const ", "import ", "import("}
+
+// scanGo tracks brace depth, raw strings and block comments to find top-level Go declarations.
+func scanGo(text string) []codeLine {
+	var (
+		lines        = strings.Split(text, "\n")
+		out          = make([]codeLine, len(lines))
+		depth        int
+		inBlock, raw bool
+	)
+	for i, line := range lines {
+		safe := !inBlock && !raw
+		out[i] = codeLine{text: line, safe: safe, decl: safe && depth == 0 && hasAnyPrefix(line, goDeclPrefixes)}
+		for j := 0; j < len(line); j++ {
+			switch ch := line[j]; {
+			case inBlock:
+				if strings.HasPrefix(line[j:], "*/") {
+					inBlock = false
+					j++
+				}
+			case raw:
+				raw = ch != '`'
+			case strings.HasPrefix(line[j:], "//"):
+				j = len(line)
+			case strings.HasPrefix(line[j:], "/*"):
+				inBlock = true
+				j++
+			case ch == '`':
+				raw = true
+			case ch == '"' || ch == '\'':
+				j = skipQuoted(line, j)
+			case ch == '{':
+				depth++
+			case ch == '}':
+				depth--
+			}
+		}
+	}
+	return out
+}
+
+var pythonDeclPrefixes = []string{"def ", "async def ", "class "}
+
+// scanPython tracks brackets and triple-quoted strings to find top-level Python
+// definitions, which start at column zero.
+func scanPython(text string) []codeLine {
+	var (
+		lines  = strings.Split(text, "\n")
+		out    = make([]codeLine, len(lines))
+		depth  int
+		triple string
+	)
+	for i, line := range lines {
+		safe := triple == ""
+		out[i] = codeLine{text: line, safe: safe, decl: safe && depth == 0 && hasAnyPrefix(line, pythonDeclPrefixes)}
+		for j := 0; j < len(line); j++ {
+			switch ch := line[j]; {
+			case triple != "":
+				if strings.HasPrefix(line[j:], triple) {
+					triple = ""
+					j += 2
+				}
+			case ch == '#':
+				j = len(line)
+			case strings.HasPrefix(line[j:], `"""`), strings.HasPrefix(line[j:], "'''"):
+				triple = line[j : j+3]
+				j += 2
+			case ch == '"' || ch == '\'':
+				j = skipQuoted(line, j)
+			case ch == '(' || ch == '[' || ch == '{':
+				depth++
+			case ch == ')' || ch == ']' || ch == '}':
+				depth--
+			}
+		}
+	}
+	return out
+}
+
+// scanPlain marks every line as safe and none as a declaration.
+func scanPlain(text string) []codeLine {
+	lines := strings.Split(text, "\n")
+	out := make([]codeLine, len(lines))
+	for i, line := range lines {
+		out[i] = codeLine{text: line, safe: true}
+	}
+	return out
+}
+
+// skipQuoted returns the index of the quote closing the literal opened at line[start],
+// or the end of the line if it is not closed.
+func skipQuoted(line string, start int) int {
+	quote := line[start]
+	for j := start + 1; j < len(line); j++ {
+		switch line[j] {
+		case '\\':
+			j++
+		case quote:
+			return j
+		}
+	}
+	return len(line)
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package chunking
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const goSource = `package demo
+
+// Add returns a + b.
+func Add(a, b int) int {
+	return a + b
+}
+
+// Greeting is a template with braces and a fake declaration inside.
+var Greeting = ` + "`" + `{
+func notADecl() {}
+}` + "`" + `
+
+func Sub(a, b int) int {
+	s := "}" // a closing brace in a string
+	return a - b
+}`
+
+const pythonSource = `import os
+
+
+@decorator
+def first():
+    """Docstring with a fake
+def not_a_def():
+    """
+    return 1
+
+
+class Second:
+    def method(self):
+        return (
+            2
+        )`
+
+func TestCodeChunker(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     string
+		maxChars int
+		text     string
+		want     []string
+	}{
+		{name: "empty", lang: "go", maxChars: 100, text: " \n ", want: nil},
+		{
+			name:     "go declarations",
+			lang:     "go",
+			maxChars: 120,
+			text:     goSource,
+			want: []string{
+				"package demo\n\n// Add returns a + b.\nfunc Add(a, b int) int {\n\treturn a + b\n}",
+				"// Greeting is a template with braces and a fake declaration inside.\nvar Greeting = `{\nfunc notADecl() {}\n}`",
+				"func Sub(a, b int) int {\n\ts := \"}\" // a closing brace in a string\n\treturn a - b\n}",
+			},
+		},
+		{
+			name:     "go packs small declarations",
+			lang:     "go",
+			maxChars: 1000,
+			text:     goSource,
+			want:     []string{goSource},
+		},
+		{
+			name:     "python definitions",
+			lang:     "python",
+			maxChars: 95,
+			text:     pythonSource,
+			want: []string{
+				"import os",
+				"@decorator\ndef first():\n    \"\"\"Docstring with a fake\ndef not_a_def():\n    \"\"\"\n    return 1",
+				"class Second:\n    def method(self):\n        return (\n            2\n        )",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCodeChunker(tt.lang, tt.maxChars).Split(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodeChunkerOversized(t *testing.T) {
+	var body strings.Builder
+	body.WriteString("func Long() {\n\ts := `\n")
+	for i := 0; i < 3; i++ {
+		body.WriteString("raw line inside string\n")
+	}
+	body.WriteString("`\n")
+	for i := 0; i < 10; i++ {
+		body.WriteString("\tstatement()\n")
+	}
+	body.WriteString("}")
+
+	chunks := NewCodeChunker("go", 100).Split(body.String())
+	if len(chunks) < 2 {
+		t.Fatalf("Split() returned %d chunks; want the oversized function split", len(chunks))
+	}
+	for _, chunk := range chunks {
+		// No chunk may begin inside the raw string literal.
+		if strings.HasPrefix(chunk, "raw line") || strings.HasPrefix(chunk, "`") {
+			t.Errorf("chunk starts inside a string literal: %q", chunk)
+		}
+	}
+	if got := strings.Join(chunks, "\n"); got != body.String() {
+		t.Errorf("chunks do not reassemble the source:\n%s", got)
+	}
+}
+
+func TestCodeChunkerNonPositiveSize(t *testing.T) {
+	for _, maxChars := range []int{0, -1} {
+		got := NewCodeChunker("plain", maxChars).Split("one\ntwo")
+		if want := []string{"one\ntwo"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Split() with maxChars %d = %q; want %q", maxChars, got, want)
+		}
+	}
+}
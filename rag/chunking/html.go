@@ -0,0 +1,131 @@
+package chunking
+
+import (
+	"html"
+	"strings"
+)
+
+var _ Chunker = (*HTMLChunker)(nil)
+
+// blockTags are the HTML elements whose boundaries are preserved as line breaks.
+var blockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true,
+	"dd": true, "div": true, "dl": true, "dt": true, "figcaption": true, "figure": true,
+	"footer": true, "form": true, "h1": true, "h2": true, "h3": true, "h4": true,
+	"h5": true, "h6": true, "header": true, "hr": true, "li": true, "main": true,
+	"nav": true, "ol": true, "p": true, "pre": true, "section": true, "table": true,
+	"td": true, "th": true, "title": true, "tr": true, "ul": true,
+}
+
+// HTMLChunker strips HTML markup with StripHTML before delegating to an inner Chunker.
+type HTMLChunker struct {
+	inner Chunker
+}
+
+// NewHTMLChunker creates an HTMLChunker that splits the extracted text with inner.
+func NewHTMLChunker(inner Chunker) *HTMLChunker {
+	return &HTMLChunker{inner: inner}
+}
+
+// Split converts HTML to plain text and splits it with the inner chunker.
+func (c *HTMLChunker) Split(text string) []string {
+	return c.inner.Split(StripHTML(text))
+}
+
+// StripHTML converts HTML to plain text. It removes tags and comments, drops the
+// contents of script and style elements, decodes entities, collapses whitespace,
+// and keeps block-level element boundaries as line breaks.
+func StripHTML(text string) string {
+	var buf strings.Builder
+	for len(text) > 0 {
+		i := strings.IndexByte(text, '<')
+		if i < 0 {
+			buf.WriteString(html.UnescapeString(text))
+			break
+		}
+		buf.WriteString(html.UnescapeString(text[:i]))
+		text = text[i:]
+		if !isTagStart(text) {
+			// A stray '<' such as in "a < b" is text, not markup.
+			buf.WriteByte('<')
+			text = text[1:]
+			continue
+		}
+		if strings.HasPrefix(text, "<!--") {
+			end := strings.Index(text, "-->")
+			if end < 0 {
+				break
+			}
+			text = text[end+len("-->"):]
+			continue
+		}
+		end := strings.IndexByte(text, '>')
+		if end < 0 {
+			break
+		}
+		name, closing := tagName(text[1:end])
+		text = text[end+1:]
+		if (name == "script" || name == "style") && !closing {
+			text = skipElement(text, name)
+			continue
+		}
+		if blockTags[name] {
+			buf.WriteByte('\n')
+		}
+	}
+	return collapseWhitespace(buf.String())
+}
+
+// isTagStart reports whether text, which begins with '<', opens a tag, comment, or
+// declaration rather than being a literal less-than sign.
+func isTagStart(text string) bool {
+	if len(text) < 2 {
+		return false
+	}
+	c := text[1]
+	return c == '/' || c == '!' || c == '?' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// tagName returns the lowercased element name of a tag body such as `a href="x"`
+// or `/p`, and whether it is a closing tag.
+func tagName(tag string) (string, bool) {
+	closing := strings.HasPrefix(tag, "/")
+	tag = strings.TrimPrefix(tag, "/")
+	end := strings.IndexAny(tag, " \t\r\n/")
+	if end >= 0 {
+		tag = tag[:end]
+	}
+	return strings.ToLower(tag), closing
+}
+
+// skipElement returns the text following the closing tag of the named element.
+// The tag name is matched case-insensitively in place, so offsets stay valid for
+// any content inside the element.
+func skipElement(text, name string) string {
+	for i := 0; ; {
+		end := strings.Index(text[i:], "</")
+		if end < 0 {
+			return ""
+		}
+		i += end + len("</")
+		if len(text)-i < len(name) || !strings.EqualFold(text[i:i+len(name)], name) {
+			continue
+		}
+		if gt := strings.IndexByte(text[i:], '>'); gt >= 0 {
+			return text[i+gt+1:]
+		}
+		return ""
+	}
+}
+
+// collapseWhitespace collapses runs of whitespace within each line and drops empty lines.
+func collapseWhitespace(text string) string {
+	lines := strings.Split(text, "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
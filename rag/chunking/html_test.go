@@ -0,0 +1,67 @@
+package chunking
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStripHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{name: "empty", html: "", want: ""},
+		{name: "plain text", html: "no   markup\there", want: "no markup here"},
+		{name: "spaced less-than", html: "<p>a < b</p>", want: "a < b"},
+		{name: "unspaced less-than", html: "x<3 and y <= 4", want: "x<3 and y <= 4"},
+		{name: "trailing less-than", html: "tail <", want: "tail <"},
+		{
+			name: "non-ASCII script",
+			html: "<script>" + strings.Repeat("Ⱥ", 100) + "</script><p>after</p>",
+			want: "after",
+		},
+		{
+			name: "non-ASCII style before text",
+			html: "<STYLE>p::before { content: \"İİİ\" }</Style>kept <p>İstanbul</p>",
+			want: "kept\nİstanbul",
+		},
+		{
+			name: "page",
+			html: `<!DOCTYPE html>
+<html>
+<head>
+  <title>Fish &amp; Chips</title>
+  <style>body { color: red; }</style>
+  <script type="text/javascript">if (a < b) { alert("x"); }</script>
+</head>
+<body>
+  <!-- navigation -->
+  <h1>Menu</h1>
+  <p>Fresh <b>cod</b>,   <a href="/x">served</a>&nbsp;hot.</p>
+  <ul><li>Salt</li><li>Vinegar &lt;malt&gt;</li></ul>
+  <p>Line one<br/>Line two</p>
+  <SCRIPT>track();</SCRIPT>
+</body>
+</html>`,
+			want: "Fish & Chips\nMenu\nFresh cod, served hot.\nSalt\nVinegar <malt>\nLine one\nLine two",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripHTML(tt.html); got != tt.want {
+				t.Errorf("StripHTML() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLChunker(t *testing.T) {
+	chunker := NewHTMLChunker(NewRecursiveChunker(20, 0, nil))
+	got := chunker.Split("<p>First paragraph.</p><p>Second paragraph.</p>")
+	want := []string{"First paragraph.", "Second paragraph."}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Split() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,155 @@
+package chunking
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+var _ Chunker = (*MarkdownChunker)(nil)
+
+const (
+	// MetadataHeading is the metadata key holding the innermost heading of a chunk.
+	MetadataHeading = "heading"
+	// MetadataHeadingPath is the metadata key holding the heading path of a chunk, e.g. "Intro > Setup".
+	MetadataHeadingPath = "heading_path"
+)
+
+// Chunk is a piece of text together with metadata describing where it came from.
+type Chunk struct {
+	Content  string         `json:"content"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// MarkdownChunker splits Markdown by heading sections, prepending each section's
+// heading to every chunk derived from it.
+type MarkdownChunker struct {
+	maxChunkSize int
+}
+
+// NewMarkdownChunker creates a MarkdownChunker that emits chunks of at most maxChunkSize characters
+// where the section heading allows it. A non-positive maxChunkSize uses DefaultChunkSize.
+func NewMarkdownChunker(maxChunkSize int) *MarkdownChunker {
+	if maxChunkSize <= 0 {
+		maxChunkSize = DefaultChunkSize
+	}
+	return &MarkdownChunker{maxChunkSize: maxChunkSize}
+}
+
+// Split splits Markdown text into chunks, never merging content across heading boundaries.
+func (c *MarkdownChunker) Split(text string) []string {
+	chunks := c.SplitWithMetadata(text)
+	if len(chunks) == 0 {
+		return nil
+	}
+	contents := make([]string, 0, len(chunks))
+	for _, chunk := range chunks {
+		contents = append(contents, chunk.Content)
+	}
+	return contents
+}
+
+// SplitWithMetadata splits Markdown text into chunks carrying the heading and heading path
+// of the section they belong to.
+func (c *MarkdownChunker) SplitWithMetadata(text string) []Chunk {
+	var chunks []Chunk
+	for _, section := range parseMarkdownSections(text) {
+		body := strings.TrimSpace(section.body)
+		if body == "" {
+			continue
+		}
+		var prefix string
+		if section.heading != "" {
+			prefix = section.heading + "\n"
+		}
+		size := c.maxChunkSize - utf8.RuneCountInString(prefix)
+		if size <= 0 {
+			size = c.maxChunkSize
+		}
+		for _, piece := range NewRecursiveChunker(size, 0, nil).Split(body) {
+			chunks = append(chunks, Chunk{
+				Content: prefix + piece,
+				Metadata: map[string]any{
+					MetadataHeading:     section.title(),
+					MetadataHeadingPath: strings.Join(section.path, " > "),
+				},
+			})
+		}
+	}
+	return chunks
+}
+
+// markdownSection is the content under a single heading.
+type markdownSection struct {
+	heading string   // the raw heading line, e.g. "## Setup"
+	path    []string // heading titles from the outermost to this section
+	body    string
+}
+
+func (s markdownSection) title() string {
+	if len(s.path) == 0 {
+		return ""
+	}
+	return s.path[len(s.path)-1]
+}
+
+// parseMarkdownSections splits Markdown into sections at ATX headings, ignoring fenced code blocks.
+func parseMarkdownSections(text string) []markdownSection {
+	var (
+		sections []markdownSection
+		current  markdownSection
+		body     strings.Builder
+		stack    []string
+		fenced   bool
+	)
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fenced = !fenced
+		}
+		level, title := parseHeading(line)
+		if fenced || level == 0 {
+			body.WriteString(line)
+			body.WriteByte('\n')
+			continue
+		}
+		current.body = body.String()
+		sections = append(sections, current)
+		body.Reset()
+		if len(stack) >= level {
+			stack = stack[:level-1]
+		}
+		for len(stack) < level-1 {
+			stack = append(stack, "")
+		}
+		stack = append(stack, title)
+		current = markdownSection{heading: trimmed, path: compactPath(stack)}
+	}
+	current.body = body.String()
+	return append(sections, current)
+}
+
+// parseHeading returns the level and title of an ATX heading line, or 0 if the line is not a heading.
+func parseHeading(line string) (int, string) {
+	if !strings.HasPrefix(line, "#") {
+		return 0, ""
+	}
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	if level > 6 {
+		return 0, ""
+	}
+	rest := line[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(strings.TrimRight(strings.TrimSpace(rest), "#"))
+}
+
+func compactPath(stack []string) []string {
+	path := make([]string, 0, len(stack))
+	for _, title := range stack {
+		if title != "" {
+			path = append(path, title)
+		}
+	}
+	return path
+}
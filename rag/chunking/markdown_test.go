@@ -0,0 +1,82 @@
+package chunking
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+const markdownDoc = `Preface text.
+
+# Intro
+Welcome to the guide.
+
+## Setup
+Install the tool. Configure the path.
+
+` + "```" + `
+# not a heading
+` + "```" + `
+
+### Linux
+Use the package manager.
+
+# Usage
+Run it.
+`
+
+func TestMarkdownChunkerSections(t *testing.T) {
+	chunks := NewMarkdownChunker(200).SplitWithMetadata(markdownDoc)
+	wantPaths := []string{"", "Intro", "Intro > Setup", "Intro > Setup > Linux", "Usage"}
+	if len(chunks) != len(wantPaths) {
+		t.Fatalf("got %d chunks; want %d: %q", len(chunks), len(wantPaths), chunks)
+	}
+	for i, chunk := range chunks {
+		if got := chunk.Metadata[MetadataHeadingPath]; got != wantPaths[i] {
+			t.Errorf("chunk %d heading path = %q; want %q", i, got, wantPaths[i])
+		}
+	}
+	if !strings.HasPrefix(chunks[2].Content, "## Setup\n") {
+		t.Errorf("chunk content %q does not start with its heading", chunks[2].Content)
+	}
+	if !strings.Contains(chunks[2].Content, "# not a heading") {
+		t.Errorf("fenced code was treated as a heading: %q", chunks[2].Content)
+	}
+	if got := chunks[3].Metadata[MetadataHeading]; got != "Linux" {
+		t.Errorf("heading = %q; want %q", got, "Linux")
+	}
+}
+
+func TestMarkdownChunkerMaxSize(t *testing.T) {
+	text := "## Details\n" + strings.Repeat("Some sentence here. ", 20)
+	chunks := NewMarkdownChunker(60).Split(text)
+	if len(chunks) < 2 {
+		t.Fatalf("expected section to be split, got %q", chunks)
+	}
+	for _, chunk := range chunks {
+		if !strings.HasPrefix(chunk, "## Details\n") {
+			t.Errorf("chunk %q is missing its heading", chunk)
+		}
+		if n := utf8.RuneCountInString(chunk); n > 60 {
+			t.Errorf("chunk %q has %d characters; want <= 60", chunk, n)
+		}
+	}
+}
+
+func TestMarkdownChunkerEmpty(t *testing.T) {
+	if got := NewMarkdownChunker(100).Split(""); got != nil {
+		t.Errorf("Split(\"\") = %q; want nil", got)
+	}
+	if got := NewMarkdownChunker(100).Split("# Title only\n"); !reflect.DeepEqual(got, []string(nil)) {
+		t.Errorf("Split() = %q; want nil", got)
+	}
+}
+
+func TestMarkdownChunkerZeroSize(t *testing.T) {
+	got := NewMarkdownChunker(0).Split("# Title\nShort body.")
+	want := []string{"# Title\nShort body."}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Split() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,133 @@
+package chunking
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+var _ Chunker = (*RecursiveChunker)(nil)
+
+// DefaultChunkSize is the chunk size in characters used when a non-positive size is given.
+const DefaultChunkSize = 1000
+
+// DefaultSeparators is the separator hierarchy used when none is provided.
+var DefaultSeparators = []string{"\n\n", "\n", ". ", " "}
+
+// RecursiveChunker splits text by trying a hierarchy of separators in order,
+// falling back to a hard character cut when no separator applies.
+type RecursiveChunker struct {
+	chunkSize  int
+	overlap    int
+	separators []string
+}
+
+// NewRecursiveChunker creates a RecursiveChunker that emits chunks of at most chunkSize
+// characters with up to overlap characters shared between adjacent chunks.
+// If separators is empty, DefaultSeparators is used. A non-positive chunkSize uses
+// DefaultChunkSize, and an overlap outside [0, chunkSize) is treated as 0.
+func NewRecursiveChunker(chunkSize, overlap int, separators []string) *RecursiveChunker {
+	if len(separators) == 0 {
+		separators = DefaultSeparators
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if overlap < 0 || overlap >= chunkSize {
+		overlap = 0
+	}
+	return &RecursiveChunker{
+		chunkSize:  chunkSize,
+		overlap:    overlap,
+		separators: separators,
+	}
+}
+
+// Split splits text into chunks, recursively breaking oversized segments with the next separator.
+func (c *RecursiveChunker) Split(text string) []string {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	var chunks []string
+	for _, chunk := range c.split(text, c.separators) {
+		if s := strings.TrimSpace(chunk); s != "" {
+			chunks = append(chunks, s)
+		}
+	}
+	return chunks
+}
+
+func (c *RecursiveChunker) split(text string, separators []string) []string {
+	if utf8.RuneCountInString(text) <= c.chunkSize {
+		return []string{text}
+	}
+	var (
+		sep  string
+		rest []string
+	)
+	for i, s := range separators {
+		if s != "" && strings.Contains(text, s) {
+			sep, rest = s, separators[i+1:]
+			break
+		}
+	}
+	if sep == "" {
+		return c.hardSplit(text)
+	}
+	var chunks, pieces []string
+	for _, piece := range strings.SplitAfter(text, sep) {
+		if piece == "" {
+			continue
+		}
+		if utf8.RuneCountInString(piece) > c.chunkSize {
+			chunks = append(chunks, c.merge(pieces)...)
+			chunks = append(chunks, c.split(piece, rest)...)
+			pieces = nil
+			continue
+		}
+		pieces = append(pieces, piece)
+	}
+	return append(chunks, c.merge(pieces)...)
+}
+
+// merge packs pieces into chunks of at most chunkSize characters, carrying
+// trailing pieces of up to overlap characters into the next chunk.
+func (c *RecursiveChunker) merge(pieces []string) []string {
+	var (
+		chunks []string
+		window []string
+		size   int
+	)
+	for _, piece := range pieces {
+		n := utf8.RuneCountInString(piece)
+		if len(window) > 0 && size+n > c.chunkSize {
+			chunks = append(chunks, strings.Join(window, ""))
+			for len(window) > 0 && (size > c.overlap || size+n > c.chunkSize) {
+				size -= utf8.RuneCountInString(window[0])
+				window = window[1:]
+			}
+		}
+		window = append(window, piece)
+		size += n
+	}
+	if len(window) > 0 {
+		chunks = append(chunks, strings.Join(window, ""))
+	}
+	return chunks
+}
+
+// hardSplit cuts text into rune-safe windows of chunkSize characters.
+func (c *RecursiveChunker) hardSplit(text string) []string {
+	var (
+		chunks []string
+		runes  = []rune(text)
+		step   = c.chunkSize - c.overlap
+	)
+	for start := 0; start < len(runes); start += step {
+		end := min(start+c.chunkSize, len(runes))
+		chunks = append(chunks, string(runes[start:end]))
+		if end == len(runes) {
+			break
+		}
+	}
+	return chunks
+}
@@ -0,0 +1,94 @@
+package chunking
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRecursiveChunker(t *testing.T) {
+	tests := []struct {
+		name       string
+		size       int
+		overlap    int
+		separators []string
+		text       string
+		want       []string
+	}{
+		{name: "empty", size: 10, text: "", want: nil},
+		{name: "fits", size: 20, text: "short text", want: []string{"short text"}},
+		{
+			name: "paragraphs",
+			size: 20,
+			text: "First paragraph.\n\nSecond paragraph.",
+			want: []string{"First paragraph.", "Second paragraph."},
+		},
+		{
+			name: "falls back to next separator",
+			size: 12,
+			text: "alpha beta gamma delta",
+			want: []string{"alpha beta", "gamma delta"},
+		},
+		{
+			name:    "overlap",
+			size:    11,
+			overlap: 6,
+			text:    "aa bb cc dd ee",
+			want:    []string{"aa bb cc", "bb cc dd ee"},
+		},
+		{
+			name:       "hard cut",
+			size:       4,
+			separators: []string{"\n"},
+			text:       "abcdefghij",
+			want:       []string{"abcd", "efgh", "ij"},
+		},
+		{
+			name:       "unicode",
+			size:       3,
+			separators: []string{"\n"},
+			text:       "你好世界和平",
+			want:       []string{"你好世", "界和平"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewRecursiveChunker(tt.size, tt.overlap, tt.separators).Split(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecursiveChunkerSize(t *testing.T) {
+	text := "Lorem ipsum dolor sit amet.\n\nConsectetur adipiscing elit, sed do eiusmod.\nTempor incididunt ut labore et dolore magna aliqua."
+	for _, chunk := range NewRecursiveChunker(25, 5, nil).Split(text) {
+		if n := utf8.RuneCountInString(chunk); n > 25 {
+			t.Errorf("chunk %q has %d characters; want <= 25", chunk, n)
+		}
+	}
+}
+
+func TestRecursiveChunkerInvalidSizes(t *testing.T) {
+	tests := []struct {
+		name      string
+		chunkSize int
+		overlap   int
+		text      string
+		want      []string
+	}{
+		{name: "zero size", chunkSize: 0, overlap: 0, text: "abc", want: []string{"abc"}},
+		{name: "negative size", chunkSize: -5, overlap: 2, text: "abc", want: []string{"abc"}},
+		{name: "overlap equal to size", chunkSize: 3, overlap: 3, text: "abcdefg", want: []string{"abc", "def", "g"}},
+		{name: "overlap larger than size", chunkSize: 3, overlap: 10, text: "abcdefg", want: []string{"abc", "def", "g"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewRecursiveChunker(tt.chunkSize, tt.overlap, nil).Split(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package chunking
+
+import (
+	"strings"
+
+	"github.com/go-kratos/blades"
+)
+
+var (
+	_ Chunker             = (*TokenChunker)(nil)
+	_ blades.TokenCounter = WhitespaceCounter{}
+)
+
+// DefaultChunkTokens is the token budget used when a non-positive maxTokens is given.
+const DefaultChunkTokens = 256
+
+// WhitespaceCounter is a blades.TokenCounter that treats each whitespace-separated word as one token.
+type WhitespaceCounter struct{}
+
+// Count returns the number of whitespace-separated words in text.
+func (WhitespaceCounter) Count(text string) int {
+	return len(strings.Fields(text))
+}
+
+// TokenChunker packs sentences into chunks bounded by a token budget.
+type TokenChunker struct {
+	maxTokens     int
+	overlapTokens int
+	counter       blades.TokenCounter
+}
+
+// NewTokenChunker creates a TokenChunker that emits chunks of at most maxTokens tokens,
+// carrying up to overlapTokens trailing tokens into the next chunk.
+// If counter is nil, WhitespaceCounter is used. A non-positive maxTokens uses DefaultChunkTokens.
+func NewTokenChunker(maxTokens, overlapTokens int, counter blades.TokenCounter) *TokenChunker {
+	if counter == nil {
+		counter = WhitespaceCounter{}
+	}
+	if maxTokens <= 0 {
+		maxTokens = DefaultChunkTokens
+	}
+	if overlapTokens < 0 {
+		overlapTokens = 0
+	}
+	return &TokenChunker{
+		maxTokens:     maxTokens,
+		overlapTokens: overlapTokens,
+		counter:       counter,
+	}
+}
+
+// Split greedily accumulates sentences until adding the next would exceed maxTokens.
+// Sentences that exceed maxTokens on their own are split on word boundaries.
+func (c *TokenChunker) Split(text string) []string {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	var (
+		chunks []string
+		buf    []string
+		fresh  bool // buf holds content beyond the carried overlap
+	)
+	for _, unit := range c.units(text) {
+		if len(buf) > 0 && c.counter.Count(joinWords(buf, unit)) > c.maxTokens {
+			if fresh {
+				chunk := joinWords(buf)
+				chunks = append(chunks, chunk)
+				buf = c.overlap(chunk)
+			} else {
+				buf = nil
+			}
+			// drop the overlap if it leaves no room for the next unit
+			if len(buf) > 0 && c.counter.Count(joinWords(buf, unit)) > c.maxTokens {
+				buf = nil
+			}
+		}
+		buf = append(buf, unit)
+		fresh = true
+	}
+	if fresh {
+		chunks = append(chunks, joinWords(buf))
+	}
+	return chunks
+}
+
+// units splits text into sentences, breaking oversized sentences into word groups.
+func (c *TokenChunker) units(text string) []string {
+	var units []string
+	for _, sentence := range splitSentences(text) {
+		if c.counter.Count(sentence) <= c.maxTokens {
+			units = append(units, sentence)
+			continue
+		}
+		var group []string
+		for _, word := range strings.Fields(sentence) {
+			if len(group) > 0 && c.counter.Count(joinWords(group, word)) > c.maxTokens {
+				units = append(units, joinWords(group))
+				group = nil
+			}
+			group = append(group, word)
+		}
+		if len(group) > 0 {
+			units = append(units, joinWords(group))
+		}
+	}
+	return units
+}
+
+// overlap returns the longest trailing run of words in chunk within overlapTokens.
+func (c *TokenChunker) overlap(chunk string) []string {
+	if c.overlapTokens == 0 || c.overlapTokens >= c.maxTokens {
+		return nil
+	}
+	words := strings.Fields(chunk)
+	start := len(words)
+	for start > 0 && c.counter.Count(joinWords(words[start-1:])) <= c.overlapTokens {
+		start--
+	}
+	if start == len(words) {
+		return nil
+	}
+	return []string{joinWords(words[start:])}
+}
+
+func joinWords(words []string, extra ...string) string {
+	return strings.Join(append(append([]string{}, words...), extra...), " ")
+}
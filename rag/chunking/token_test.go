@@ -0,0 +1,86 @@
+package chunking
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-kratos/blades"
+)
+
+func TestTokenChunker(t *testing.T) {
+	tests := []struct {
+		name    string
+		max     int
+		overlap int
+		text    string
+		want    []string
+	}{
+		{name: "empty", max: 10, text: "", want: nil},
+		{name: "whitespace", max: 10, text: "  \n\t ", want: nil},
+		{
+			name: "single chunk",
+			max:  10,
+			text: "The quick fox. It jumps.",
+			want: []string{"The quick fox. It jumps."},
+		},
+		{
+			name: "sentence boundaries",
+			max:  5,
+			text: "One two three. Four five six. Seven.",
+			want: []string{"One two three.", "Four five six. Seven."},
+		},
+		{
+			name:    "overlap",
+			max:     5,
+			overlap: 2,
+			text:    "One two three. Four five six. Seven eight.",
+			want:    []string{"One two three.", "two three. Four five six.", "five six. Seven eight."},
+		},
+		{
+			name: "zero max uses default",
+			max:  0,
+			text: "One two three. Four five six.",
+			want: []string{"One two three. Four five six."},
+		},
+		{
+			name: "negative max uses default",
+			max:  -1,
+			text: "One two three.",
+			want: []string{"One two three."},
+		},
+		{
+			name: "oversized sentence",
+			max:  3,
+			text: "a b c d e f g.",
+			want: []string{"a b c", "d e f", "g."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewTokenChunker(tt.max, tt.overlap, nil).Split(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenChunkerBudget(t *testing.T) {
+	text := "Alpha beta gamma delta. Epsilon zeta eta. Theta iota kappa lambda mu. Nu xi omicron pi rho sigma tau upsilon."
+	counter := WhitespaceCounter{}
+	for _, chunk := range NewTokenChunker(6, 3, counter).Split(text) {
+		if n := counter.Count(chunk); n > 6 {
+			t.Errorf("chunk %q has %d tokens; want <= 6", chunk, n)
+		}
+	}
+}
+
+func TestTokenChunkerBladesCounter(t *testing.T) {
+	// Any blades.TokenCounter works, e.g. one character per token.
+	counter := blades.TokenCounterFunc(func(text string) int { return len(text) })
+	got := NewTokenChunker(10, 0, counter).Split("abc def. ghi jkl.")
+	want := []string{"abc def.", "ghi jkl."}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Split() = %q; want %q", got, want)
+	}
+}
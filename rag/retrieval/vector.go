@@ -0,0 +1,52 @@
+package retrieval
+
+import "math"
+
+// DotProduct returns the dot product of a and b.
+// It returns 0 when the lengths differ or either vector is empty.
+func DotProduct(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var sum float64
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// CosineSimilarity returns the cosine of the angle between a and b.
+// It returns 0 when the lengths differ, either vector is empty, or either has zero magnitude.
+func CosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	normA, normB := norm(a), norm(b)
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return DotProduct(a, b) / (normA * normB)
+}
+
+// NormalizeVector returns a unit-length copy of v.
+// A zero-magnitude vector is returned as a copy unchanged.
+func NormalizeVector(v []float64) []float64 {
+	out := make([]float64, len(v))
+	n := norm(v)
+	if n == 0 {
+		copy(out, v)
+		return out
+	}
+	for i, x := range v {
+		out[i] = x / n
+	}
+	return out
+}
+
+func norm(v []float64) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += x * x
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,81 @@
+package retrieval
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+const epsilon = 1e-9
+
+func TestDotProduct(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{name: "empty", a: nil, b: nil, want: 0},
+		{name: "mismatched", a: []float64{1, 2}, b: []float64{1}, want: 0},
+		{name: "orthogonal", a: []float64{1, 0}, b: []float64{0, 1}, want: 0},
+		{name: "basic", a: []float64{1, 2, 3}, b: []float64{4, 5, 6}, want: 32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DotProduct(tt.a, tt.b); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("DotProduct() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{name: "empty", a: []float64{}, b: []float64{1}, want: 0},
+		{name: "mismatched", a: []float64{1, 2}, b: []float64{1, 2, 3}, want: 0},
+		{name: "zero vector", a: []float64{0, 0}, b: []float64{1, 1}, want: 0},
+		{name: "orthogonal", a: []float64{1, 0}, b: []float64{0, 3}, want: 0},
+		{name: "parallel", a: []float64{1, 2}, b: []float64{2, 4}, want: 1},
+		{name: "opposite", a: []float64{1, 1}, b: []float64{-1, -1}, want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CosineSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("CosineSimilarity() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeVector(t *testing.T) {
+	tests := []struct {
+		name string
+		v    []float64
+		want []float64
+	}{
+		{name: "empty", v: []float64{}, want: []float64{}},
+		{name: "zero vector", v: []float64{0, 0}, want: []float64{0, 0}},
+		{name: "basic", v: []float64{3, 4}, want: []float64{0.6, 0.8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeVector(tt.v)
+			if len(got) != len(tt.want) {
+				t.Fatalf("NormalizeVector() = %v; want %v", got, tt.want)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > epsilon {
+					t.Fatalf("NormalizeVector() = %v; want %v", got, tt.want)
+				}
+			}
+		})
+	}
+	v := []float64{3, 4}
+	NormalizeVector(v)
+	if !reflect.DeepEqual(v, []float64{3, 4}) {
+		t.Errorf("NormalizeVector modified its input: %v", v)
+	}
+}
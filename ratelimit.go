@@ -0,0 +1,36 @@
+package blades
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitMiddleware returns a Middleware that throttles runs to rps per second,
+// allowing bursts of up to burst runs. Each run blocks until a token is available
+// or the context is done, in which case the context error is returned.
+func RateLimitMiddleware(rps float64, burst int) Middleware {
+	limiter := rate.NewLimiter(rate.Limit(rps), burst)
+	return func(next Runnable) Runnable {
+		return &rateLimitMiddleware{next: next, limiter: limiter}
+	}
+}
+
+type rateLimitMiddleware struct {
+	next    Runnable
+	limiter *rate.Limiter
+}
+
+func (m *rateLimitMiddleware) Run(ctx context.Context, p *Prompt, opts ...ModelOption) (*Message, error) {
+	if err := m.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return m.next.Run(ctx, p, opts...)
+}
+
+func (m *rateLimitMiddleware) RunStream(ctx context.Context, p *Prompt, opts ...ModelOption) (Streamable[*Message], error) {
+	if err := m.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return m.next.RunStream(ctx, p, opts...)
+}
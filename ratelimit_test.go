@@ -0,0 +1,35 @@
+package blades
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	next := &HandleFunc{
+		Handle: func(ctx context.Context, p *Prompt, _ ...ModelOption) (*Message, error) {
+			return AssistantMessage("OK"), nil
+		},
+	}
+	h := RateLimitMiddleware(20, 1)(next)
+
+	const calls = 5
+	start := time.Now()
+	for i := 0; i < calls; i++ {
+		if _, err := h.Run(context.Background(), NewPrompt(UserMessage("test"))); err != nil {
+			t.Fatalf("Run() error: %v", err)
+		}
+	}
+	// The first call consumes the burst; each remaining call waits 1/rps.
+	if elapsed, min := time.Since(start), (calls-1)*50*time.Millisecond; elapsed < min {
+		t.Fatalf("%d calls took %v; want at least %v", calls, elapsed, min)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := h.Run(ctx, NewPrompt(UserMessage("test"))); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() with cancelled context error = %v; want %v", err, context.Canceled)
+	}
+}
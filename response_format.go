@@ -0,0 +1,70 @@
+package blades
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// ResponseFormatType identifies the output format requested from the model.
+type ResponseFormatType string
+
+const (
+	// ResponseFormatJSONObject requests any valid JSON object.
+	ResponseFormatJSONObject ResponseFormatType = "json_object"
+	// ResponseFormatJSONSchema requests JSON that conforms to a schema.
+	ResponseFormatJSONSchema ResponseFormatType = "json_schema"
+)
+
+// ResponseFormat describes the output format an Agent requests from the model
+// and validates its final output against.
+type ResponseFormat struct {
+	Type   ResponseFormatType `json:"type"`
+	Schema *jsonschema.Schema `json:"schema,omitempty"`
+	target reflect.Type
+	err    error
+}
+
+// JSONObject requests that the model respond with a JSON object.
+var JSONObject = ResponseFormat{Type: ResponseFormatJSONObject}
+
+// JSONSchema requests JSON conforming to the schema inferred from v's type,
+// e.g. JSONSchema(Answer{}). The final output must unmarshal into that type.
+func JSONSchema(v any) ResponseFormat {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ResponseFormat{Type: ResponseFormatJSONSchema, err: fmt.Errorf("response format: nil schema type")}
+	}
+	schema, err := jsonschema.ForType(t, &jsonschema.ForOptions{})
+	if err != nil {
+		return ResponseFormat{Type: ResponseFormatJSONSchema, err: fmt.Errorf("response format: %w", err)}
+	}
+	return ResponseFormat{Type: ResponseFormatJSONSchema, Schema: schema, target: t}
+}
+
+// Validate reports whether the message text is valid output for the format.
+func (f *ResponseFormat) Validate(m *Message) error {
+	text := strings.TrimSpace(m.Text())
+	switch f.Type {
+	case ResponseFormatJSONObject:
+		var v map[string]any
+		if err := json.Unmarshal([]byte(text), &v); err != nil {
+			return fmt.Errorf("response format: invalid JSON object: %w", err)
+		}
+	case ResponseFormatJSONSchema:
+		if f.target == nil {
+			return nil
+		}
+		v := reflect.New(f.target).Interface()
+		if err := json.Unmarshal([]byte(text), v); err != nil {
+			return fmt.Errorf("response format: output does not match %s: %w", f.target, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package blades
+
+import (
+	"context"
+	"testing"
+)
+
+type answer struct {
+	Answer     string  `json:"answer"`
+	Confidence float64 `json:"confidence"`
+}
+
+func TestResponseFormatValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  ResponseFormat
+		text    string
+		wantErr bool
+	}{
+		{name: "json object", format: JSONObject, text: `{"a": 1}`},
+		{name: "json object invalid", format: JSONObject, text: `not json`, wantErr: true},
+		{name: "json object array", format: JSONObject, text: `[1, 2]`, wantErr: true},
+		{name: "json schema", format: JSONSchema(answer{}), text: `{"answer": "42", "confidence": 0.9}`},
+		{name: "json schema pointer", format: JSONSchema(&answer{}), text: `{"answer": "42"}`},
+		{name: "json schema mismatch", format: JSONSchema(answer{}), text: `{"answer": 42}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.format.Validate(AssistantMessage(tt.text))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v; wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAgentResponseFormat(t *testing.T) {
+	provider := &mockProvider{text: `{"answer": "42", "confidence": 0.9}`}
+	agent := NewAgent("test", WithProvider(provider), WithResponseFormat(JSONSchema(answer{})))
+	if _, err := agent.Run(context.Background(), NewPrompt(UserMessage("question"))); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	req := provider.requests[0]
+	if req.ResponseFormat == nil || req.ResponseFormat.Type != ResponseFormatJSONSchema || req.OutputSchema == nil {
+		t.Fatalf("request missing response format: %+v", req)
+	}
+
+	provider.text = "forty-two"
+	if _, err := agent.Run(context.Background(), NewPrompt(UserMessage("question"))); err == nil {
+		t.Fatal("expected error for output that does not match the schema")
+	}
+}
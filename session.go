@@ -2,6 +2,8 @@ package blades
 
 import (
 	"context"
+	"slices"
+	"sync"
 
 	"github.com/go-kratos/generics"
 	"github.com/google/uuid"
@@ -19,6 +21,9 @@ type Session struct {
 	ID      string                   `json:"id"`
 	History generics.Slice[*Message] `json:"history"`
 	State   State                    `json:"state"`
+
+	mu       sync.Mutex
+	recorded map[string]struct{} // IDs of messages in History, built lazily by Record
 }
 
 // PutState sets a key-value pair in the session state.
@@ -26,12 +31,36 @@ func (s *Session) PutState(key string, value any) {
 	s.State.Store(key, value)
 }
 
-// Record records the input prompt and output message under the given name.
+// Record records the input prompt and output message in the session history.
+// Messages whose ID is already recorded are skipped, so re-running the same
+// prompt (e.g. in a loop or on retry) does not duplicate history entries.
+// Recorded IDs are tracked in a set seeded from History on first use, so messages
+// appended to History directly afterwards are not deduplicated.
 func (s *Session) Record(input []*Message, output *Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.recorded == nil {
+		s.recorded = make(map[string]struct{}, s.History.Len())
+		s.History.Range(func(_ int, m *Message) bool {
+			if m.ID != "" {
+				s.recorded[m.ID] = struct{}{}
+			}
+			return true
+		})
+	}
 	messages := make([]*Message, 0, len(input)+1)
 	messages = append(messages, input...)
 	messages = append(messages, output)
-	s.History.Append(messages...)
+	s.History.Append(slices.DeleteFunc(messages, func(m *Message) bool {
+		if m.ID == "" {
+			return false
+		}
+		if _, ok := s.recorded[m.ID]; ok {
+			return true
+		}
+		s.recorded[m.ID] = struct{}{}
+		return false
+	})...)
 }
 
 // NewSession creates a new Session instance with a unique ID.
@@ -0,0 +1,77 @@
+package blades
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSessionRecordDeduplicates(t *testing.T) {
+	session := NewSession()
+	ctx := NewSessionContext(context.Background(), session)
+	agent := NewAgent("test", WithProvider(&mockProvider{text: "ok"}))
+	prompt := NewPrompt(UserMessage("hello"))
+	// Run the same prompt twice, as a loop or retry would.
+	for i := 0; i < 2; i++ {
+		if _, err := agent.Run(ctx, prompt); err != nil {
+			t.Fatalf("Run() error: %v", err)
+		}
+	}
+	var roles []Role
+	session.History.Range(func(_ int, m *Message) bool {
+		roles = append(roles, m.Role)
+		return true
+	})
+	want := []Role{RoleUser, RoleAssistant, RoleAssistant}
+	if len(roles) != len(want) {
+		t.Fatalf("history roles = %v; want %v", roles, want)
+	}
+	for i := range want {
+		if roles[i] != want[i] {
+			t.Fatalf("history roles = %v; want %v", roles, want)
+		}
+	}
+}
+
+func TestSessionRecordKeepsMessagesWithoutID(t *testing.T) {
+	session := NewSession()
+	output := &Message{Role: RoleAssistant, Parts: Parts("ok")}
+	session.Record(nil, output)
+	session.Record(nil, output)
+	if n := session.History.Len(); n != 2 {
+		t.Fatalf("history length = %d; want 2", n)
+	}
+}
+
+func TestSessionRecordDeduplicatesInstructions(t *testing.T) {
+	session := NewSession()
+	ctx := NewSessionContext(context.Background(), session)
+	agent := NewAgent("test", WithProvider(&mockProvider{text: "ok"}), WithInstructions("Be brief."))
+	for _, text := range []string{"first", "second"} {
+		if _, err := agent.Run(ctx, NewPrompt(UserMessage(text))); err != nil {
+			t.Fatalf("Run() error: %v", err)
+		}
+	}
+	var got []string
+	session.History.Range(func(_ int, m *Message) bool {
+		got = append(got, string(m.Role)+":"+m.Text())
+		return true
+	})
+	want := []string{"system:Be brief.", "user:first", "assistant:ok", "user:second", "assistant:ok"}
+	if len(got) != len(want) {
+		t.Fatalf("history = %q; want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("history = %q; want %q", got, want)
+		}
+	}
+}
+
+func TestSessionRecordKeepsRepeatedSystemMessages(t *testing.T) {
+	session := NewSession()
+	session.Record([]*Message{SystemMessage("Reminder: stay on topic."), UserMessage("a")}, AssistantMessage("ok"))
+	session.Record([]*Message{SystemMessage("Reminder: stay on topic."), UserMessage("b")}, AssistantMessage("ok"))
+	if n := session.History.Len(); n != 6 {
+		t.Fatalf("history length = %d; want 6", n)
+	}
+}
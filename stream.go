@@ -1,6 +1,10 @@
 package blades
 
-import "sync/atomic"
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
 
 // MappedStream maps the output of one Streamer to another type.
 type MappedStream[M any, T any] struct {
@@ -34,6 +38,7 @@ func (ws *MappedStream[M, T]) Close() error {
 
 // StreamPipe directs the yielding of values.
 type StreamPipe[T any] struct {
+	mu     sync.Mutex
 	err    error
 	closed atomic.Bool
 	queue  chan T
@@ -51,6 +56,16 @@ func (d *StreamPipe[T]) Send(v T) {
 	d.queue <- v
 }
 
+// SendContext sends v, giving up with ctx.Err() if ctx is done before the consumer takes it.
+func (d *StreamPipe[T]) SendContext(ctx context.Context, v T) error {
+	select {
+	case d.queue <- v:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Next returns true if there is a value to yield.
 func (d *StreamPipe[T]) Next() bool {
 	v, ok := <-d.queue
@@ -63,6 +78,8 @@ func (d *StreamPipe[T]) Next() bool {
 
 // Current returns the value and marks it as yielded.
 func (d *StreamPipe[T]) Current() (T, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	return d.next, d.err
 }
 
@@ -70,7 +87,10 @@ func (d *StreamPipe[T]) Current() (T, error) {
 func (d *StreamPipe[T]) Go(fn func() error) {
 	go func() {
 		defer d.Close()
-		d.err = fn()
+		err := fn()
+		d.mu.Lock()
+		d.err = err
+		d.mu.Unlock()
 	}()
 }
 
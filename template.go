@@ -3,6 +3,7 @@ package blades
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"text/template"
 )
@@ -26,7 +27,9 @@ type templateText struct {
 //
 // Exported aliases (User/System/Build) are also provided for external packages.
 type PromptTemplate struct {
-	tmpls []*templateText
+	tmpls  []*templateText
+	funcs  template.FuncMap
+	strict bool
 }
 
 // NewPromptTemplate creates a new PromptTemplate builder.
@@ -34,6 +37,57 @@ func NewPromptTemplate() *PromptTemplate {
 	return &PromptTemplate{}
 }
 
+// WithFuncs registers custom template functions (e.g. {{upper .Name}}) available to all templates.
+// Functions are registered before parsing; later registrations override earlier ones with the same name.
+func (p *PromptTemplate) WithFuncs(funcs template.FuncMap) *PromptTemplate {
+	if p.funcs == nil {
+		p.funcs = make(template.FuncMap, len(funcs))
+	}
+	for name, fn := range funcs {
+		p.funcs[name] = fn
+	}
+	return p
+}
+
+// WithDefaultFuncs registers the default template functions: upper, lower, join, and default.
+//
+//	{{upper .Name}} {{lower .Name}} {{join .Items ", "}} {{default "anonymous" .Name}}
+func (p *PromptTemplate) WithDefaultFuncs() *PromptTemplate {
+	return p.WithFuncs(DefaultTemplateFuncs())
+}
+
+// WithStrictVars makes Build fail when a template references a map key that is not defined,
+// instead of rendering "<no value>". Missing struct fields are always an error.
+func (p *PromptTemplate) WithStrictVars() *PromptTemplate {
+	p.strict = true
+	return p
+}
+
+// DefaultTemplateFuncs returns the default template function map registered by WithDefaultFuncs.
+func DefaultTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"join": func(items any, sep string) string {
+			v := reflect.ValueOf(items)
+			if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+				return fmt.Sprint(items)
+			}
+			elems := make([]string, 0, v.Len())
+			for i := 0; i < v.Len(); i++ {
+				elems = append(elems, fmt.Sprint(v.Index(i).Interface()))
+			}
+			return strings.Join(elems, sep)
+		},
+		"default": func(fallback, value any) any {
+			if value == nil || reflect.ValueOf(value).IsZero() {
+				return fallback
+			}
+			return value
+		},
+	}
+}
+
 // User appends a user message rendered from the provided template and one or more parameter maps.
 // Each map is merged in order; later maps override keys from earlier maps. The merged map is accessible in the template (e.g., {{.name}}).
 func (p *PromptTemplate) User(tmpl string, vars ...map[string]any) *PromptTemplate {
@@ -80,7 +134,7 @@ func (p *PromptTemplate) System(tmpl string, vars ...map[string]any) *PromptTemp
 func (p *PromptTemplate) Build() (*Prompt, error) {
 	messages := make([]*Message, 0, len(p.tmpls))
 	for _, tmpl := range p.tmpls {
-		message, err := NewTemplateMessage(tmpl.role, tmpl.template, tmpl.vars)
+		message, err := newTemplateMessage(tmpl.role, tmpl.template, tmpl.vars, p.funcs, p.strict)
 		if err != nil {
 			return nil, err
 		}
@@ -103,7 +157,7 @@ func (p *PromptTemplate) BuildContext(ctx context.Context) (*Prompt, error) {
 		for k, v := range tmpl.vars {
 			state[k] = v
 		}
-		message, err := NewTemplateMessage(tmpl.role, tmpl.template, state)
+		message, err := newTemplateMessage(tmpl.role, tmpl.template, state, p.funcs, p.strict)
 		if err != nil {
 			return nil, err
 		}
@@ -112,10 +166,54 @@ func (p *PromptTemplate) BuildContext(ctx context.Context) (*Prompt, error) {
 	return NewPrompt(messages...), nil
 }
 
+// TemplateOption configures how NewTemplateMessage renders its template.
+type TemplateOption func(*templateOptions)
+
+// templateOptions holds the rendering settings for NewTemplateMessage.
+type templateOptions struct {
+	funcs  template.FuncMap
+	strict bool
+}
+
+// WithTemplateFuncs registers custom template functions for NewTemplateMessage, as
+// PromptTemplate.WithFuncs does for a builder. Pass DefaultTemplateFuncs() for the defaults.
+func WithTemplateFuncs(funcs template.FuncMap) TemplateOption {
+	return func(o *templateOptions) {
+		if o.funcs == nil {
+			o.funcs = make(template.FuncMap, len(funcs))
+		}
+		for name, fn := range funcs {
+			o.funcs[name] = fn
+		}
+	}
+}
+
+// WithTemplateStrictVars makes NewTemplateMessage fail on a missing map key, as
+// PromptTemplate.WithStrictVars does for a builder.
+func WithTemplateStrictVars() TemplateOption {
+	return func(o *templateOptions) {
+		o.strict = true
+	}
+}
+
 // NewTemplateMessage creates a single Message by rendering the provided template string with the given variables.
-func NewTemplateMessage(role Role, tmpl string, vars any) (*Message, error) {
+func NewTemplateMessage(role Role, tmpl string, vars any, opts ...TemplateOption) (*Message, error) {
+	var o templateOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return newTemplateMessage(role, tmpl, vars, o.funcs, o.strict)
+}
+
+// newTemplateMessage renders a single Message with the given template functions registered before parsing.
+// In strict mode, a missing map key is an execution error rather than "<no value>".
+func newTemplateMessage(role Role, tmpl string, vars any, funcs template.FuncMap, strict bool) (*Message, error) {
 	var buf strings.Builder
-	t, err := template.New("message").Parse(tmpl)
+	t := template.New("message").Funcs(funcs)
+	if strict {
+		t = t.Option("missingkey=error")
+	}
+	t, err := t.Parse(tmpl)
 	if err != nil {
 		return nil, err
 	}
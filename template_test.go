@@ -1,6 +1,9 @@
 package blades
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestTemplateMessage(t *testing.T) {
 	tmpl := "Hello, {{.Name}}! Welcome to {{.Place}}."
@@ -30,3 +33,118 @@ func TestTemplateMessageEmpty(t *testing.T) {
 		t.Errorf("TemplateMessage = %q; want %q", result, expected)
 	}
 }
+
+func TestTemplateFuncs(t *testing.T) {
+	data := map[string]any{
+		"Name":  "Alice",
+		"Items": []string{"apples", "pears"},
+	}
+	tests := []struct {
+		name    string
+		tmpl    string
+		build   func(*PromptTemplate) *PromptTemplate
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "default funcs",
+			tmpl:  `{{upper .Name}} {{lower .Name}} likes {{join .Items ", "}} and {{default "nobody" .Missing}}`,
+			build: (*PromptTemplate).WithDefaultFuncs,
+			want:  "ALICE alice likes apples, pears and nobody",
+		},
+		{
+			name: "custom funcs",
+			tmpl: `{{greet .Name}}`,
+			build: func(p *PromptTemplate) *PromptTemplate {
+				return p.WithFuncs(map[string]any{"greet": func(s string) string { return "Hi " + s }})
+			},
+			want: "Hi Alice",
+		},
+		{
+			name:    "unknown func",
+			tmpl:    `{{upper .Name}}`,
+			build:   func(p *PromptTemplate) *PromptTemplate { return p },
+			wantErr: `function "upper" not defined`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.build(NewPromptTemplate()).User(tt.tmpl, data).Build()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Build() error: %v", err)
+			}
+			if got := result.Latest().Text(); got != tt.want {
+				t.Errorf("Build() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplateMessageFuncs(t *testing.T) {
+	vars := map[string]any{"Name": "Alice"}
+	if _, err := NewTemplateMessage(RoleUser, "{{upper .Name}}", vars); err == nil {
+		t.Fatal("expected error for unregistered function")
+	}
+	greet := WithTemplateFuncs(map[string]any{"greet": func(s string) string { return "Hi " + s }})
+	msg, err := NewTemplateMessage(RoleUser, "{{greet .Name}}, {{upper .Name}}", vars, greet, WithTemplateFuncs(DefaultTemplateFuncs()))
+	if err != nil {
+		t.Fatalf("NewTemplateMessage() error: %v", err)
+	}
+	if got, want := msg.Text(), "Hi Alice, ALICE"; got != want {
+		t.Errorf("NewTemplateMessage() = %q; want %q", got, want)
+	}
+}
+
+func TestTemplateStrictVars(t *testing.T) {
+	tests := []struct {
+		name    string
+		strict  bool
+		vars    map[string]any
+		want    string
+		wantErr bool
+	}{
+		{name: "lenient", vars: map[string]any{"Question": "why?"}, want: "Q: <no value>"},
+		{name: "strict missing", strict: true, vars: map[string]any{"Question": "why?"}, wantErr: true},
+		{name: "strict defined", strict: true, vars: map[string]any{"Qeustion": "why?"}, want: "Q: why?"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := NewPromptTemplate()
+			if tt.strict {
+				tmpl = tmpl.WithStrictVars()
+			}
+			result, err := tmpl.User("Q: {{.Qeustion}}", tt.vars).Build()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for missing variable, got %q", result.Latest().Text())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Build() error: %v", err)
+			}
+			if got := result.Latest().Text(); got != tt.want {
+				t.Errorf("Build() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+	t.Run("NewTemplateMessage", func(t *testing.T) {
+		vars := map[string]any{"Question": "why?"}
+		msg, err := NewTemplateMessage(RoleUser, "Q: {{.Qeustion}}", vars)
+		if err != nil {
+			t.Fatalf("NewTemplateMessage() error: %v", err)
+		}
+		if got, want := msg.Text(), "Q: <no value>"; got != want {
+			t.Errorf("NewTemplateMessage() = %q; want %q", got, want)
+		}
+		if _, err := NewTemplateMessage(RoleUser, "Q: {{.Qeustion}}", vars, WithTemplateStrictVars()); err == nil {
+			t.Fatal("expected error for missing map key with WithTemplateStrictVars")
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package blades
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeoutMiddleware returns a Middleware that bounds each run to d. When the
+// duration elapses the run fails with an error wrapping ErrTimeout. A tighter
+// deadline already set on the caller's context still applies and surfaces as
+// the caller's context error.
+func TimeoutMiddleware(d time.Duration) Middleware {
+	return func(next Runnable) Runnable {
+		return &timeoutMiddleware{next: next, timeout: d}
+	}
+}
+
+type timeoutMiddleware struct {
+	next    Runnable
+	timeout time.Duration
+}
+
+func (m *timeoutMiddleware) Run(ctx context.Context, p *Prompt, opts ...ModelOption) (*Message, error) {
+	tctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+	output, err := m.next.Run(tctx, p, opts...)
+	if err != nil {
+		return nil, m.timeoutErr(ctx, tctx, err)
+	}
+	return output, nil
+}
+
+func (m *timeoutMiddleware) RunStream(ctx context.Context, p *Prompt, opts ...ModelOption) (Streamable[*Message], error) {
+	tctx, cancel := context.WithTimeout(ctx, m.timeout)
+	stream, err := m.next.RunStream(tctx, p, opts...)
+	if err != nil {
+		cancel()
+		return nil, m.timeoutErr(ctx, tctx, err)
+	}
+	pipe := NewStreamPipe[*Message]()
+	pipe.Go(func() error {
+		defer cancel()
+		for stream.Next() {
+			output, err := stream.Current()
+			if err != nil {
+				return m.timeoutErr(ctx, tctx, err)
+			}
+			pipe.Send(output)
+		}
+		return m.timeoutErr(ctx, tctx, nil)
+	})
+	return pipe, nil
+}
+
+// timeoutErr reports ErrTimeout when the middleware's own deadline expired,
+// and returns err unchanged otherwise.
+func (m *timeoutMiddleware) timeoutErr(parent, tctx context.Context, err error) error {
+	if parent.Err() == nil && errors.Is(tctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrTimeout, m.timeout)
+	}
+	return err
+}
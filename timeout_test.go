@@ -0,0 +1,71 @@
+package blades
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	slow := &HandleFunc{
+		Handle: func(ctx context.Context, p *Prompt, _ ...ModelOption) (*Message, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Second):
+				return AssistantMessage("OK"), nil
+			}
+		},
+		HandleStream: func(ctx context.Context, p *Prompt, _ ...ModelOption) (Streamable[*Message], error) {
+			pipe := NewStreamPipe[*Message]()
+			pipe.Go(func() error {
+				pipe.Send(AssistantMessage("partial"))
+				<-ctx.Done()
+				return ctx.Err()
+			})
+			return pipe, nil
+		},
+	}
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		parent  time.Duration
+		wantErr error
+	}{
+		{name: "timeout", timeout: 10 * time.Millisecond, wantErr: ErrTimeout},
+		{name: "tighter caller deadline", timeout: time.Minute, parent: 10 * time.Millisecond, wantErr: context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.parent > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.parent)
+				defer cancel()
+			}
+			h := TimeoutMiddleware(tt.timeout)(slow)
+			_, err := h.Run(ctx, NewPrompt(UserMessage("test")))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Run() error = %v; want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != ErrTimeout && errors.Is(err, ErrTimeout) {
+				t.Fatalf("Run() error = %v; caller deadline should not be reported as ErrTimeout", err)
+			}
+		})
+	}
+	t.Run("stream", func(t *testing.T) {
+		stream, err := TimeoutMiddleware(10*time.Millisecond)(slow).RunStream(context.Background(), NewPrompt(UserMessage("test")))
+		if err != nil {
+			t.Fatalf("RunStream() error: %v", err)
+		}
+		for stream.Next() {
+			if _, err = stream.Current(); err != nil {
+				break
+			}
+		}
+		if _, err = stream.Current(); !errors.Is(err, ErrTimeout) {
+			t.Fatalf("stream error = %v; want %v", err, ErrTimeout)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package blades
+
+import "unicode/utf8"
+
+// MessageTokenOverhead is the number of tokens added per message to account for
+// role markers and separators used by chat-style models.
+const MessageTokenOverhead = 4
+
+// TokenCounter counts the number of tokens in a piece of text.
+type TokenCounter interface {
+	Count(text string) int
+}
+
+// TokenCounterFunc adapts a plain function to a TokenCounter.
+type TokenCounterFunc func(text string) int
+
+// Count is the Count method of the TokenCounter interface.
+func (f TokenCounterFunc) Count(text string) int {
+	return f(text)
+}
+
+// DefaultTokenCounter estimates tokens with the common heuristic of about four
+// characters per token. It is used when no counter is given.
+var DefaultTokenCounter TokenCounter = TokenCounterFunc(func(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+})
+
+// EstimateTokens estimates the number of tokens in the prompt by summing the counted
+// tokens of each message plus MessageTokenOverhead per message. A nil counter
+// uses DefaultTokenCounter.
+func EstimateTokens(prompt *Prompt, counter TokenCounter) int {
+	if prompt == nil {
+		return 0
+	}
+	if counter == nil {
+		counter = DefaultTokenCounter
+	}
+	var total int
+	for _, m := range prompt.Messages {
+		total += counter.Count(m.Text()) + MessageTokenOverhead
+	}
+	return total
+}
+
+// EstimateTokens estimates the number of tokens in the prompt. See EstimateTokens.
+func (p *Prompt) EstimateTokens(counter TokenCounter) int {
+	return EstimateTokens(p, counter)
+}
@@ -0,0 +1,48 @@
+package blades
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	counter := TokenCounterFunc(func(text string) int {
+		return len(strings.Fields(text))
+	})
+	tests := []struct {
+		name   string
+		prompt *Prompt
+		want   int
+	}{
+		{name: "nil", prompt: nil, want: 0},
+		{name: "empty", prompt: NewPrompt(), want: 0},
+		{
+			name: "multi-message",
+			prompt: NewPrompt(
+				SystemMessage("You are a helpful assistant."),
+				UserMessage("What is the capital of France?"),
+				AssistantMessage("Paris."),
+			),
+			want: 5 + 6 + 1 + 3*MessageTokenOverhead,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateTokens(tt.prompt, counter); got != tt.want {
+				t.Errorf("EstimateTokens() = %d; want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromptEstimateTokens(t *testing.T) {
+	prompt := NewPrompt(UserMessage("abcdefgh"), AssistantMessage("abcde"))
+	// "abcdefgh" is 2 tokens and "abcde" rounds up to 2 at ~4 characters per token.
+	want := 2 + 2 + 2*MessageTokenOverhead
+	if got := prompt.EstimateTokens(nil); got != want {
+		t.Errorf("EstimateTokens(nil) = %d; want %d", got, want)
+	}
+	if got := prompt.EstimateTokens(DefaultTokenCounter); got != want {
+		t.Errorf("EstimateTokens(DefaultTokenCounter) = %d; want %d", got, want)
+	}
+}
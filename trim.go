@@ -0,0 +1,109 @@
+package blades
+
+import "context"
+
+// TrimStrategy reduces a prompt so that its estimated size fits within maxTokens.
+// Strategies return a new prompt and must not modify the given one.
+type TrimStrategy func(ctx context.Context, prompt *Prompt, maxTokens int, counter TokenCounter) (*Prompt, error)
+
+// TrimOption configures TrimHistoryMiddleware.
+type TrimOption func(*trimProvider)
+
+// WithTrimStrategy sets the strategy used to trim the request messages. The default is DropOldest.
+func WithTrimStrategy(strategy TrimStrategy) TrimOption {
+	return func(p *trimProvider) {
+		p.strategy = strategy
+	}
+}
+
+// TrimHistoryMiddleware returns a ProviderMiddleware that trims the messages of each
+// model request, including the agent instructions and memory, so that their estimated
+// token count, as computed by EstimateTokens, fits under maxTokens. The agent's own
+// request is left untouched. A nil counter uses DefaultTokenCounter.
+func TrimHistoryMiddleware(maxTokens int, counter TokenCounter, opts ...TrimOption) ProviderMiddleware {
+	if counter == nil {
+		counter = DefaultTokenCounter
+	}
+	return func(next ModelProvider) ModelProvider {
+		p := &trimProvider{
+			next:      next,
+			maxTokens: maxTokens,
+			counter:   counter,
+			strategy:  DropOldest,
+		}
+		for _, opt := range opts {
+			opt(p)
+		}
+		return p
+	}
+}
+
+type trimProvider struct {
+	next      ModelProvider
+	maxTokens int
+	counter   TokenCounter
+	strategy  TrimStrategy
+}
+
+// trim returns a copy of req whose messages fit the budget, or req itself when they already fit.
+func (p *trimProvider) trim(ctx context.Context, req *ModelRequest) (*ModelRequest, error) {
+	prompt := NewPrompt(req.Messages...)
+	if EstimateTokens(prompt, p.counter) <= p.maxTokens {
+		return req, nil
+	}
+	trimmed, err := p.strategy(ctx, prompt, p.maxTokens, p.counter)
+	if err != nil {
+		return nil, err
+	}
+	r := *req
+	r.Messages = trimmed.Messages
+	return &r, nil
+}
+
+func (p *trimProvider) Generate(ctx context.Context, req *ModelRequest, opts ...ModelOption) (*ModelResponse, error) {
+	trimmed, err := p.trim(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return p.next.Generate(ctx, trimmed, opts...)
+}
+
+func (p *trimProvider) NewStream(ctx context.Context, req *ModelRequest, opts ...ModelOption) (Streamable[*ModelResponse], error) {
+	trimmed, err := p.trim(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return p.next.NewStream(ctx, trimmed, opts...)
+}
+
+// DropOldest is a TrimStrategy that drops the oldest messages until the prompt fits.
+// System messages and the most recent user message are always kept, so the result
+// may still exceed maxTokens when they alone do not fit.
+func DropOldest(ctx context.Context, prompt *Prompt, maxTokens int, counter TokenCounter) (*Prompt, error) {
+	lastUser := -1
+	for i := len(prompt.Messages) - 1; i >= 0; i-- {
+		if prompt.Messages[i].Role == RoleUser {
+			lastUser = i
+			break
+		}
+	}
+	total := EstimateTokens(prompt, counter)
+	dropped := make([]bool, len(prompt.Messages))
+	for i, msg := range prompt.Messages {
+		if total <= maxTokens {
+			break
+		}
+		if msg.Role == RoleSystem || i == lastUser {
+			continue
+		}
+		dropped[i] = true
+		total -= counter.Count(msg.Text()) + MessageTokenOverhead
+	}
+	messages := make([]*Message, 0, len(prompt.Messages))
+	for i, msg := range prompt.Messages {
+		if !dropped[i] {
+			messages = append(messages, msg)
+		}
+	}
+	return NewPrompt(messages...), nil
+}
@@ -0,0 +1,102 @@
+package blades
+
+import (
+	"context"
+	"testing"
+)
+
+func requestTexts(req *ModelRequest) []string {
+	return promptTexts(NewPrompt(req.Messages...))
+}
+
+func TestTrimHistoryMiddleware(t *testing.T) {
+	// Each message costs its text length plus MessageTokenOverhead.
+	counter := TokenCounterFunc(func(text string) int { return len(text) })
+	history := []*Message{
+		UserMessage("u1"),
+		AssistantMessage("a1"),
+		UserMessage("u2"),
+		AssistantMessage("a2"),
+		UserMessage("u3"),
+	}
+	size := func(texts ...string) int {
+		var n int
+		for _, text := range texts {
+			n += len(text) + MessageTokenOverhead
+		}
+		return n
+	}
+	tests := []struct {
+		name      string
+		maxTokens int
+		want      []string
+	}{
+		{name: "fits", maxTokens: 1000, want: []string{"sys", "u1", "a1", "u2", "a2", "u3"}},
+		{name: "drop oldest", maxTokens: size("sys", "u2", "a2", "u3"), want: []string{"sys", "u2", "a2", "u3"}},
+		{name: "keep system and last user", maxTokens: 1, want: []string{"sys", "u3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &mockProvider{text: "ok"}
+			agent := NewAgent("test",
+				WithProvider(provider),
+				WithInstructions("sys"),
+				WithProviderMiddleware(TrimHistoryMiddleware(tt.maxTokens, counter)),
+			)
+			prompt := NewPrompt(history...)
+			if _, err := agent.Run(context.Background(), prompt); err != nil {
+				t.Fatalf("Run() error: %v", err)
+			}
+			got := requestTexts(provider.requests[0])
+			if len(got) != len(tt.want) {
+				t.Fatalf("provider request = %q; want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("provider request = %q; want %q", got, tt.want)
+				}
+			}
+			if got[0] != "sys" || provider.requests[0].Messages[0].Role != RoleSystem {
+				t.Fatalf("instructions were not kept as the first message: %q", got)
+			}
+			if len(prompt.Messages) != len(history) {
+				t.Fatalf("original prompt was modified: %q", promptTexts(prompt))
+			}
+		})
+	}
+}
+
+func TestTrimHistoryMiddlewareStream(t *testing.T) {
+	provider := &mockProvider{text: "ok"}
+	agent := NewAgent("test",
+		WithProvider(provider),
+		WithInstructions("sys"),
+		WithProviderMiddleware(TrimHistoryMiddleware(1, nil)),
+	)
+	stream, err := agent.RunStream(context.Background(), NewPrompt(UserMessage("old question"), AssistantMessage("old answer"), UserMessage("new")))
+	if err != nil {
+		t.Fatalf("RunStream() error: %v", err)
+	}
+	for stream.Next() {
+	}
+	if got := requestTexts(provider.requests[0]); len(got) != 2 || got[0] != "sys" || got[1] != "new" {
+		t.Fatalf("provider request = %q; want [sys new]", got)
+	}
+}
+
+func TestTrimHistoryMiddlewareStrategy(t *testing.T) {
+	summarize := func(ctx context.Context, p *Prompt, maxTokens int, counter TokenCounter) (*Prompt, error) {
+		return NewPrompt(SystemMessage("summary"), p.LastOf(RoleUser)), nil
+	}
+	provider := &mockProvider{text: "ok"}
+	agent := NewAgent("test",
+		WithProvider(provider),
+		WithProviderMiddleware(TrimHistoryMiddleware(1, nil, WithTrimStrategy(summarize))),
+	)
+	if _, err := agent.Run(context.Background(), NewPrompt(UserMessage("long question"), AssistantMessage("long answer"), UserMessage("next"))); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if got := requestTexts(provider.requests[0]); len(got) != 2 || got[0] != "summary" || got[1] != "next" {
+		t.Fatalf("provider request = %q; want [summary next]", got)
+	}
+}